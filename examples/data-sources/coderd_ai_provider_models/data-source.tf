@@ -0,0 +1,30 @@
+resource "coderd_ai_provider" "anthropic" {
+  type               = "anthropic"
+  name               = "anthropic"
+  api_key_wo         = var.anthropic_api_key
+  api_key_wo_version = 1
+}
+
+data "coderd_ai_provider_models" "anthropic" {
+  ai_provider_id = coderd_ai_provider.anthropic.id
+}
+
+locals {
+  // for_each keys must be known at plan time, so list the models to offer
+  // here and look up their metadata in the catalog.
+  anthropic_models = toset(["claude-sonnet-4-5", "claude-opus-4-1"])
+
+  anthropic_catalog = {
+    for model in data.coderd_ai_provider_models.anthropic.models :
+    model.model => model
+  }
+}
+
+resource "coderd_agents_model" "anthropic" {
+  for_each = local.anthropic_models
+
+  ai_provider_id = coderd_ai_provider.anthropic.id
+  model          = each.key
+  display_name   = try(local.anthropic_catalog[each.key].display_name, each.key)
+  context_limit  = coalesce(try(local.anthropic_catalog[each.key].context_limit, null), 200000)
+}
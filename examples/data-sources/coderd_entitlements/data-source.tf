@@ -0,0 +1,14 @@
+data "coderd_entitlements" "deployment" {}
+
+// Only manage workspace proxies when the license grants them
+resource "coderd_workspace_proxy" "sydney" {
+  count = data.coderd_entitlements.deployment.features["workspace_proxy"].enabled ? 1 : 0
+
+  name         = "sydney"
+  display_name = "Australia (Sydney)"
+  icon         = "/emojis/1f1e6-1f1fa.png"
+}
+
+output "license_warnings" {
+  value = data.coderd_entitlements.deployment.warnings
+}
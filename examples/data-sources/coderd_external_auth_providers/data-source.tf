@@ -0,0 +1,17 @@
+data "coderd_external_auth_providers" "all" {}
+
+resource "coderd_template" "ubuntu-main" {
+  name = "ubuntu-main"
+  versions = [{
+    directory = "./ubuntu-main"
+    active    = true
+  }]
+
+  lifecycle {
+    // The template's coder_external_auth data source references "github".
+    precondition {
+      condition     = contains([for provider in data.coderd_external_auth_providers.all.providers : provider.id], "github")
+      error_message = "The deployment has no GitHub external auth provider."
+    }
+  }
+}
@@ -0,0 +1,15 @@
+data "coderd_template" "ubuntu" {
+  name = "ubuntu-main"
+}
+
+data "coderd_template_versions" "ubuntu" {
+  template_id = data.coderd_template.ubuntu.id
+}
+
+locals {
+  // The newest version that built successfully on the `gpu` provisioners
+  latest_gpu_version = reverse([
+    for v in data.coderd_template_versions.ubuntu.versions : v
+    if v.job_status == "succeeded" && lookup(v.provisioner_tags, "gpu", "") == "true"
+  ])[0]
+}
@@ -0,0 +1,12 @@
+// The provider authenticates as a service account that builds workspaces
+// from a template requiring GitHub.
+data "coderd_user_external_auth" "github" {
+  provider_id = "github"
+}
+
+check "github_linked" {
+  assert {
+    condition     = data.coderd_user_external_auth.github.authenticated
+    error_message = "The service account hasn't linked a usable GitHub account."
+  }
+}
@@ -0,0 +1,12 @@
+data "coderd_user" "developer" {
+  username = "developer"
+}
+
+// Get the user's quota in the provider default organization
+data "coderd_user_quota" "developer" {
+  user_id = data.coderd_user.developer.id
+}
+
+output "developer_quota_remaining" {
+  value = data.coderd_user_quota.developer.budget - data.coderd_user_quota.developer.credits_consumed
+}
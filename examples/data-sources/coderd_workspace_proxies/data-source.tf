@@ -0,0 +1,9 @@
+data "coderd_workspace_proxies" "all" {}
+
+// The app URLs of the regions currently serving traffic
+output "healthy_proxy_urls" {
+  value = {
+    for proxy in data.coderd_workspace_proxies.all.proxies :
+    proxy.name => proxy.url if proxy.healthy
+  }
+}
@@ -0,0 +1,22 @@
+resource "coderd_workspace_proxy" "sydney" {
+  name         = "sydney"
+  display_name = "Australia (Sydney)"
+  icon         = "/emojis/1f1e6-1f1fa.png"
+}
+
+// The deployment running the proxy with coderd_workspace_proxy.sydney.session_token
+resource "kubernetes_deployment" "sydney_wsproxy" {
+  /* ... */
+}
+
+// Read once the proxy has registered and passed a health check
+data "coderd_workspace_proxy" "sydney" {
+  id               = coderd_workspace_proxy.sydney.id
+  wait_for_healthy = "10m"
+
+  depends_on = [kubernetes_deployment.sydney_wsproxy]
+}
+
+output "sydney_wildcard_hostname" {
+  value = data.coderd_workspace_proxy.sydney.wildcard_hostname
+}
@@ -0,0 +1,17 @@
+// List every workspace still running an outdated template version
+data "coderd_workspaces" "outdated" {
+  outdated = true
+}
+
+// List the running workspaces created from a single template
+data "coderd_workspaces" "ubuntu" {
+  template = "ubuntu-main"
+  status   = "running"
+}
+
+output "outdated_workspaces" {
+  value = {
+    for ws in data.coderd_workspaces.outdated.workspaces :
+    "${ws.owner_name}/${ws.name}" => ws.template_version_id
+  }
+}
@@ -17,4 +17,16 @@ resource "coderd_agents_mcp_server" "example" {
   tool_allow_list = ["search", "read_document"]
   enabled         = true
   model_intent    = true
+
+  # Tools are discovered whether or not verify is set, and plans warn about
+  # tool_allow_list entries the server doesn't advertise. Additionally check
+  # that the server answers with this key after every apply, and fail the
+  # apply if it doesn't.
+  verify = {
+    fail_on_error = true
+  }
+}
+
+output "internal_search_tools" {
+  value = coderd_agents_mcp_server.example.tools
 }
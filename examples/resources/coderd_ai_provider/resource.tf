@@ -40,4 +40,25 @@ resource "coderd_ai_provider" "openai" {
 
   api_key_wo         = var.openai_api_key
   api_key_wo_version = 1
+
+  # Warn if OpenAI rejects the key after it's set or rotated.
+  verify = {}
+}
+
+resource "coderd_ai_provider" "vertex" {
+  type     = "anthropic"
+  name     = "vertex-anthropic"
+  base_url = "https://us-east5-aiplatform.googleapis.com"
+
+  settings = {
+    vertex = {
+      project  = "my-gcp-project"
+      location = "us-east5"
+
+      // Omit these to use the Google Application Default Credentials of the
+      // Coder server process (for example an attached service account).
+      // credentials_json_wo    = var.vertex_service_account_key
+      // credentials_wo_version = 1
+    }
+  }
 }
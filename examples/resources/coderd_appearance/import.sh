@@ -0,0 +1,3 @@
+# The appearance settings are a deployment-wide singleton, so the import ID is
+# required by the CLI syntax but otherwise unused.
+terraform import coderd_appearance.this appearance
@@ -0,0 +1,16 @@
+resource "coderd_appearance" "this" {
+  application_name = "Acme Dev"
+  logo_url         = "https://example.com/logo.png"
+
+  announcement_banners = [
+    {
+      message = "Scheduled maintenance on **Saturday 10:00 UTC**."
+    },
+    {
+      // Keep the banner in configuration but hide it.
+      message          = "New templates are available."
+      background_color = "#1f6feb"
+      enabled          = false
+    },
+  ]
+}
@@ -0,0 +1,10 @@
+# The ID supplied must be the integer ID of a license
+$ terraform import coderd_license.license 1
+```
+Alternatively, in Terraform v1.5.0 and later, an [`import` block](https://developer.hashicorp.com/terraform/language/import) can be used:
+
+```terraform
+import {
+  to = coderd_license.license
+  id = "1"
+}
@@ -1,7 +1,6 @@
 resource "coderd_license" "license" {
   license = "<…>"
 
-  lifecycle {
-    create_before_destroy = true
-  }
+  // Warn when planning in the two months before the license expires
+  warn_before_expiry = "1440h"
 }
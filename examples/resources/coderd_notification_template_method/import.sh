@@ -0,0 +1,10 @@
+# The ID supplied must be a notification template UUID
+$ terraform import coderd_notification_template_method.workspace_deleted f517da0b-cdc9-410f-ab89-a86107c420ed
+```
+Alternatively, in Terraform v1.5.0 and later, an [`import` block](https://developer.hashicorp.com/terraform/language/import) can be used:
+
+```terraform
+import {
+  to = coderd_notification_template_method.workspace_deleted
+  id = "f517da0b-cdc9-410f-ab89-a86107c420ed"
+}
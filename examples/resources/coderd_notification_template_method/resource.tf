@@ -0,0 +1,6 @@
+// Send "Workspace Deleted" notifications to the webhook, whatever the
+// deployment's default method is.
+resource "coderd_notification_template_method" "workspace_deleted" {
+  template_id = "f517da0b-cdc9-410f-ab89-a86107c420ed"
+  method      = "webhook"
+}
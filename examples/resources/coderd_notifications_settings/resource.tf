@@ -0,0 +1,10 @@
+// Pause notification dispatch during a maintenance window. Notifications
+// are still enqueued, and are sent once the notifier is resumed.
+resource "coderd_notifications_settings" "this" {
+  notifier_paused = var.maintenance_window
+}
+
+variable "maintenance_window" {
+  type    = bool
+  default = false
+}
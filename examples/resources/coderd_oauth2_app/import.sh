@@ -0,0 +1,10 @@
+# The ID supplied must be an OAuth2 app UUID
+$ terraform import coderd_oauth2_app.grafana <app-id>
+```
+Alternatively, in Terraform v1.5.0 and later, an [`import` block](https://developer.hashicorp.com/terraform/language/import) can be used:
+
+```terraform
+import {
+  to = coderd_oauth2_app.grafana
+  id = "<app-id>"
+}
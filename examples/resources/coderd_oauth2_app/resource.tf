@@ -0,0 +1,13 @@
+resource "coderd_oauth2_app" "grafana" {
+  name          = "grafana"
+  callback_urls = ["https://grafana.example.com/login/generic_oauth"]
+  icon          = "/icon/grafana.svg"
+}
+
+resource "coderd_oauth2_app_secret" "grafana" {
+  app_id = coderd_oauth2_app.grafana.id
+}
+
+output "grafana_client_id" {
+  value = coderd_oauth2_app.grafana.id
+}
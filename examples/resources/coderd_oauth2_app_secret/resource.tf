@@ -0,0 +1,18 @@
+resource "coderd_oauth2_app" "grafana" {
+  name          = "grafana"
+  callback_urls = ["https://grafana.example.com/login/generic_oauth"]
+}
+
+// Rotate the secret every 90 days.
+resource "time_rotating" "grafana" {
+  rotation_days = 90
+}
+
+resource "coderd_oauth2_app_secret" "grafana" {
+  app_id           = coderd_oauth2_app.grafana.id
+  rotation_trigger = time_rotating.grafana.id
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,10 @@
+# The ID supplied must be an organization UUID
+$ terraform import coderd_organization_role_sync.main_role_sync <org-id>
+```
+Alternatively, in Terraform v1.5.0 and later, an [`import` block](https://developer.hashicorp.com/terraform/language/import) can be used:
+
+```terraform
+import {
+  to = coderd_organization_role_sync.main_role_sync
+  id = "<org-id>"
+}
@@ -0,0 +1,9 @@
+resource "coderd_organization_role_sync" "test" {
+  organization_id = coderd_organization.test.id
+  field           = "roles"
+
+  mapping = {
+    "admins"   = ["organization-admin"]
+    "auditors" = ["organization-auditor", "organization-template-admin"]
+  }
+}
@@ -6,6 +6,14 @@ variable "COMMIT_SHA" {
   type = string
 }
 
+variable "STAGING_ORG_ID" {
+  type = string
+}
+
+variable "PRODUCTION_ORG_ID" {
+  type = string
+}
+
 resource "coderd_user" "coder1" {
   username = "coder1"
   name     = "Coder One"
@@ -35,3 +43,22 @@ resource "coderd_template" "ubuntu-main" {
     groups = []
   }
 }
+
+// Promote the version tested in the staging organization to production
+// without re-uploading the directory.
+data "coderd_template" "ubuntu-staging" {
+  organization_id = var.STAGING_ORG_ID
+  name            = "ubuntu-main"
+}
+
+resource "coderd_template" "ubuntu-production" {
+  organization_id = var.PRODUCTION_ORG_ID
+  name            = "ubuntu-main"
+  versions = [
+    {
+      name              = "stable-${var.COMMIT_SHA}"
+      source_version_id = data.coderd_template.ubuntu-staging.active_version_id
+      active            = true
+    }
+  ]
+}
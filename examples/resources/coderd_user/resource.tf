@@ -35,3 +35,24 @@ resource "coderd_user" "automation" {
   roles              = ["template-admin"]
   is_service_account = true
 }
+
+// Stop a developer's workspaces overnight in their own timezone (Premium),
+// and set their dashboard preferences.
+resource "coderd_user" "developer" {
+  username             = "developer"
+  email                = "developer@example.com"
+  quiet_hours_schedule = "CRON_TZ=Europe/London 0 2 * * *"
+  appearance = {
+    theme_preference = "dark"
+    terminal_font    = "fira-code"
+  }
+}
+
+// Suspend a departing developer rather than deleting them, stopping their
+// workspaces and handing their templates to the Jenkins bot user.
+resource "coderd_user" "departing" {
+  username              = "departing"
+  email                 = "departing@example.com"
+  on_destroy            = "suspend_and_stop_workspaces"
+  transfer_templates_to = coderd_user.jenkins.id
+}
@@ -0,0 +1,23 @@
+resource "coderd_user" "ci" {
+  username           = "ci"
+  is_service_account = true
+}
+
+resource "time_rotating" "gitssh" {
+  rotation_days = 90
+}
+
+// Rotate the CI account's GitSSH key every 90 days.
+resource "coderd_user_gitsshkey" "ci" {
+  user_id          = coderd_user.ci.id
+  rotation_trigger = time_rotating.gitssh.id
+}
+
+// Register the current key as a deploy key. A rotation updates it in the
+// same apply.
+resource "github_repository_deploy_key" "ci" {
+  title      = "Coder CI"
+  repository = "infrastructure"
+  key        = coderd_user_gitsshkey.ci.public_key
+  read_only  = true
+}
@@ -0,0 +1,10 @@
+# The ID supplied must be a user UUID
+$ terraform import coderd_user_notification_preferences.ci <user-id>
+```
+Alternatively, in Terraform v1.5.0 and later, an [`import` block](https://developer.hashicorp.com/terraform/language/import) can be used:
+
+```terraform
+import {
+  to = coderd_user_notification_preferences.ci
+  id = "<user-id>"
+}
@@ -0,0 +1,14 @@
+resource "coderd_user" "ci" {
+  username           = "ci"
+  is_service_account = true
+}
+
+// Nobody reads the CI account's notifications, so opt it out of the
+// workspace lifecycle ones.
+resource "coderd_user_notification_preferences" "ci" {
+  user_id = coderd_user.ci.id
+  disabled_template_ids = [
+    "f517da0b-cdc9-410f-ab89-a86107c420ed", // Workspace Deleted
+    "0ea69165-ec14-4314-91f1-69566ac3c5a0", // Workspace Marked as Dormant
+  ]
+}
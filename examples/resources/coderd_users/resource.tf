@@ -0,0 +1,23 @@
+// Provider populated from environemnt variables
+provider "coderd" {}
+
+// Manage the whole team from a CSV export, e.g.
+//
+//   username,name,email,roles,suspended
+//   alice,Alice Smith,alice@example.com,template-admin;auditor,false
+//   bob,Bob Jones,bob@example.com,,true
+locals {
+  team = csvdecode(file("${path.module}/users.csv"))
+}
+
+resource "coderd_users" "team" {
+  users = {
+    for u in local.team : u.username => {
+      name       = u.name
+      email      = u.email
+      roles      = u.roles == "" ? [] : split(";", u.roles)
+      login_type = "oidc"
+      suspended  = u.suspended == "true"
+    }
+  }
+}
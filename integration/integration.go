@@ -29,6 +29,7 @@ type coderOptions struct {
 	image            string
 	version          string
 	experiments      string
+	env              map[string]string
 }
 
 func UseLicense(opts *coderOptions) {
@@ -54,6 +55,14 @@ func EnableRateLimits(opts *coderOptions) {
 	opts.enableRateLimits = true
 }
 
+// CoderEnv sets additional environment variables on the coder container, for
+// deployment options that have no dedicated option here.
+func CoderEnv(env map[string]string) func(opts *coderOptions) {
+	return func(opts *coderOptions) {
+		opts.env = env
+	}
+}
+
 func StartCoder(ctx context.Context, t *testing.T, name string, options ...func(*coderOptions)) *codersdk.Client {
 	// Start with the defaults.
 	opts := coderOptions{
@@ -104,6 +113,9 @@ func StartCoder(ctx context.Context, t *testing.T, name string, options ...func(
 	if opts.experiments != "" {
 		env["CODER_EXPERIMENTS"] = opts.experiments
 	}
+	for k, v := range opts.env {
+		env[k] = v
+	}
 
 	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: testcontainers.ContainerRequest{
@@ -0,0 +1,15 @@
+package codersdkvalidator
+
+import (
+	"github.com/coder/coder/v2/coderd/schedule/cron"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func checkQuietHoursSchedule(it string) error {
+	_, err := cron.Daily(it)
+	return err
+}
+
+func QuietHoursSchedule() validator.String {
+	return validatorFromFunc(checkQuietHoursSchedule, "value must be a valid daily cron schedule, such as CRON_TZ=Europe/London 0 2 * * *")
+}
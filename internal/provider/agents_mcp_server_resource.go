@@ -2,8 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/google/uuid"
@@ -74,6 +79,10 @@ type AgentsMCPServerResourceModel struct {
 	ModelIntent                 types.Bool   `tfsdk:"model_intent"`
 	AllowInPlanMode             types.Bool   `tfsdk:"allow_in_plan_mode"`
 	ForwardCoderHeaders         types.Bool   `tfsdk:"forward_coder_headers"`
+	DiscoverTools               types.Bool   `tfsdk:"discover_tools"`
+	Verify                      types.Object `tfsdk:"verify"`
+	LastVerifiedAt              types.Int64  `tfsdk:"last_verified_at"`
+	Tools                       types.Set    `tfsdk:"tools"`
 	CreatedAt                   types.Int64  `tfsdk:"created_at"`
 	UpdatedAt                   types.Int64  `tfsdk:"updated_at"`
 }
@@ -104,6 +113,10 @@ func (r *AgentsMCPServerResource) ModifyPlan(ctx context.Context, req resource.M
 			return
 		}
 	}
+	if tools, ok := r.advertisedTools(ctx, plan, config, state, &resp.Diagnostics); ok {
+		warnUnknownTools(&resp.Diagnostics, "tool_allow_list", stringSetElements(ctx, plan.ToolAllowList, &resp.Diagnostics), tools)
+		warnUnknownTools(&resp.Diagnostics, "tool_deny_list", stringSetElements(ctx, plan.ToolDenyList, &resp.Diagnostics), tools)
+	}
 	entering := !hasState || !plan.AuthType.Equal(state.AuthType)
 
 	if !entering {
@@ -368,14 +381,14 @@ func (r *AgentsMCPServerResource) Schema(ctx context.Context, req resource.Schem
 				Optional:            true,
 			},
 			"tool_allow_list": schema.SetAttribute{
-				MarkdownDescription: "Tool names that are allowed. An empty set allows all tools unless denied.",
+				MarkdownDescription: "Tool names that are allowed. An empty set allows all tools unless denied. Plans warn about names the server doesn't advertise; see `tools`.",
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
 				Default:             setdefault.StaticValue(emptyStringSet),
 			},
 			"tool_deny_list": schema.SetAttribute{
-				MarkdownDescription: "Tool names that are denied.",
+				MarkdownDescription: "Tool names that are denied. Plans warn about names the server doesn't advertise; see `tools`.",
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
@@ -405,6 +418,24 @@ func (r *AgentsMCPServerResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"discover_tools": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform lists the server's tools, from the machine running Terraform, to populate `tools` and warn about `tool_allow_list` and `tool_deny_list` entries that aren't among them. It authenticates like `verify`. Defaults to true.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"verify": verifySchema("the server completes an MCP `initialize` handshake and lists its tools, authenticating with `api_key_value_wo` or `custom_headers_wo`. " +
+				"For `oauth2` and `user_oidc` servers, which authenticate as each Coder user, and when the write-only credentials aren't in the configuration, a server that rejects the unauthenticated handshake still passes the check, but its tools aren't listed"),
+			"last_verified_at": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp of the last `verify` check that passed. Null if `verify` is omitted or no check has passed.",
+				Computed:            true,
+			},
+			"tools": schema.SetAttribute{
+				MarkdownDescription: "Names of the tools the server listed the last time they were discovered after a create or update. Null if `discover_tools` is false, discovery has never succeeded, or the server required per-user authentication to list them. " +
+					"Plans warn about `tool_allow_list` and `tool_deny_list` entries that aren't among the server's tools: with `discover_tools` set, plans that change the server's URL, transport, credentials, or tool lists discover the tools, and other plans, or a failed discovery, use this list.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"created_at": schema.Int64Attribute{
 				MarkdownDescription: "Unix timestamp when the MCP server configuration was created.",
 				Computed:            true,
@@ -536,6 +567,10 @@ func (r *AgentsMCPServerResource) Create(ctx context.Context, req resource.Creat
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r.setVerification(ctx, &state, AgentsMCPServerResourceModel{
+		LastVerifiedAt: types.Int64Null(),
+		Tools:          types.SetNull(types.StringType),
+	}, config, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -584,6 +619,7 @@ func (r *AgentsMCPServerResource) Update(ctx context.Context, req resource.Updat
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r.setVerification(ctx, &updated, state, config, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updated)...)
 }
 
@@ -816,8 +852,175 @@ func (m AgentsMCPServerResourceModel) stateFromServer(server codersdk.MCPServerC
 		ModelIntent:                 types.BoolValue(server.ModelIntent),
 		AllowInPlanMode:             types.BoolValue(server.AllowInPlanMode),
 		ForwardCoderHeaders:         types.BoolValue(server.ForwardCoderHeaders),
-		CreatedAt:                   types.Int64Value(server.CreatedAt.Unix()),
-		UpdatedAt:                   types.Int64Value(server.UpdatedAt.Unix()),
+		// Verification and discovery happen in Terraform, not Coder.
+		DiscoverTools:  discoverToolsOrDefault(m.DiscoverTools),
+		Verify:         verifyOrNull(m.Verify),
+		LastVerifiedAt: m.LastVerifiedAt,
+		Tools:          m.Tools,
+		CreatedAt:      types.Int64Value(server.CreatedAt.Unix()),
+		UpdatedAt:      types.Int64Value(server.UpdatedAt.Unix()),
+	}
+}
+
+// discoverToolsOrDefault returns v, or the schema default for the zero value
+// of a model that wasn't decoded from Terraform, such as the one built on
+// import.
+func discoverToolsOrDefault(v types.Bool) types.Bool {
+	if v.IsNull() {
+		return types.BoolValue(true)
+	}
+	return v
+}
+
+// setVerification runs the `verify` check and tool discovery after an apply,
+// which share a single request to the server. It sets last_verified_at to
+// null without `verify`, and otherwise to the time of this check, or that in
+// prior if it fails. It sets tools to null without `discover_tools`, and
+// otherwise to the tools listed now, or those in prior if listing them fails.
+func (r *AgentsMCPServerResource) setVerification(ctx context.Context, state *AgentsMCPServerResourceModel, prior, config AgentsMCPServerResourceModel, diags *diag.Diagnostics) {
+	verify := verifyModel(ctx, state.Verify, diags)
+	discover := state.DiscoverTools.ValueBool()
+	state.LastVerifiedAt = types.Int64Null()
+	state.Tools = types.SetNull(types.StringType)
+	if verify == nil && !discover {
+		return
+	}
+	if verify != nil {
+		state.LastVerifiedAt = prior.LastVerifiedAt
+	}
+	if discover {
+		state.Tools = prior.Tools
+	}
+
+	// Without credentials the handshake can only prove the server is
+	// reachable, so a rejection still passes.
+	headers, authenticated := state.verifyHeaders(ctx, config, diags)
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout(verify))
+	defer cancel()
+	tflog.Info(ctx, "verifying MCP server", map[string]any{"url": state.URL.ValueString(), "authenticated": authenticated})
+	tools, err := listMCPTools(ctx, &http.Client{}, r.providerVersion(), state.Transport.ValueString(), state.URL.ValueString(), headers)
+	var httpErr *verifyHTTPError
+	switch {
+	case err == nil:
+		if discover {
+			state.Tools = stringSetValue(tools)
+		}
+	case !authenticated && errors.As(err, &httpErr) && httpErr.Unauthorized():
+		state.Tools = types.SetNull(types.StringType)
+	case verify != nil:
+		verify.reportFailure(diags, "MCP Server Verification Failed",
+			fmt.Sprintf("Unable to verify MCP server %q at %s: %s", state.Slug.ValueString(), state.URL.ValueString(), err))
+		return
+	default:
+		diags.AddAttributeWarning(path.Root("discover_tools"), "MCP Tool Discovery Failed",
+			fmt.Sprintf("Unable to list the tools of MCP server %q at %s, so `tools` keeps its previous value: %s. Set `discover_tools = false` if Terraform can't reach the server.", state.Slug.ValueString(), state.URL.ValueString(), err))
+		return
+	}
+	if verify != nil {
+		state.LastVerifiedAt = types.Int64Value(time.Now().Unix())
+	}
+}
+
+// providerVersion returns the provider version to report to MCP servers.
+// The provider isn't configured while validating a configuration.
+func (r *AgentsMCPServerResource) providerVersion() string {
+	if r.data == nil {
+		return "dev"
+	}
+	return r.data.Version
+}
+
+// discoveryTimeout bounds a request to the server: the `verify` timeout if
+// it's set, and the default otherwise.
+func discoveryTimeout(verify *VerifyModel) time.Duration {
+	if verify == nil {
+		return defaultVerifyTimeout
+	}
+	return verify.timeout()
+}
+
+// verifyHeaders returns the headers that authenticate Terraform's own
+// requests to the server, and whether they do. Servers that authenticate each
+// Coder user, and write-only credentials missing from config, leave requests
+// unauthenticated.
+func (m AgentsMCPServerResourceModel) verifyHeaders(ctx context.Context, config AgentsMCPServerResourceModel, diags *diag.Diagnostics) (http.Header, bool) {
+	headers := http.Header{}
+	switch m.AuthType.ValueString() {
+	case "none":
+		return headers, true
+	case "api_key":
+		if !config.APIKeyValueWO.IsNull() && !config.APIKeyValueWO.IsUnknown() {
+			headers.Set(m.APIKeyHeader.ValueString(), config.APIKeyValueWO.ValueString())
+			return headers, true
+		}
+	case "custom_headers":
+		if customHeaders := writeOnlyStringMap(ctx, config.CustomHeadersWO, diags); len(customHeaders) > 0 {
+			for name, value := range customHeaders {
+				headers.Set(name, value)
+			}
+			return headers, true
+		}
+	}
+	return headers, false
+}
+
+// advertisedTools returns the server's tools for plan-time checks: discovered
+// now if `discover_tools` is set and the plan changes the server's connection
+// or tool lists, or otherwise those listed by the last discovery against the
+// same URL. ok is false if neither is available.
+func (r *AgentsMCPServerResource) advertisedTools(ctx context.Context, plan, config, state AgentsMCPServerResourceModel, diags *diag.Diagnostics) (tools []string, ok bool) {
+	if plan.DiscoverTools.ValueBool() && plan.toolsMayChange(state) && !plan.URL.IsUnknown() && !plan.Transport.IsUnknown() && !plan.APIKeyHeader.IsUnknown() {
+		headers, _ := plan.verifyHeaders(ctx, config, diags)
+		discoverCtx, cancel := context.WithTimeout(ctx, discoveryTimeout(verifyModel(ctx, plan.Verify, diags)))
+		defer cancel()
+		discovered, err := listMCPTools(discoverCtx, &http.Client{}, r.providerVersion(), plan.Transport.ValueString(), plan.URL.ValueString(), headers)
+		if err == nil {
+			return discovered, true
+		}
+		// Discovery after apply reports why the server can't be reached.
+		tflog.Debug(ctx, "unable to discover MCP server tools", map[string]any{"url": plan.URL.ValueString(), "error": err.Error()})
+	}
+	if state.Tools.IsNull() || state.Tools.IsUnknown() || !state.URL.Equal(plan.URL) {
+		return nil, false
+	}
+	return stringSetElements(ctx, state.Tools, diags), true
+}
+
+// toolsMayChange reports whether the plan changes anything that decides which
+// tools the server advertises to Terraform, or which of them the tool lists
+// name, relative to state. Other plans reuse the tools in state rather than
+// contacting the server.
+func (m AgentsMCPServerResourceModel) toolsMayChange(state AgentsMCPServerResourceModel) bool {
+	// url is required, so it is only null without state.
+	return state.URL.IsNull() ||
+		!m.URL.Equal(state.URL) ||
+		!m.Transport.Equal(state.Transport) ||
+		!m.AuthType.Equal(state.AuthType) ||
+		!m.APIKeyHeader.Equal(state.APIKeyHeader) ||
+		writeOnlyVersionChanged(m.APIKeyValueWOVersion, state.APIKeyValueWOVersion) ||
+		writeOnlyVersionChanged(m.CustomHeadersWOVersion, state.CustomHeadersWOVersion) ||
+		!m.ToolAllowList.Equal(state.ToolAllowList) ||
+		!m.ToolDenyList.Equal(state.ToolDenyList)
+}
+
+// warnUnknownTools warns about entries in a tool allow or deny list that name
+// none of the tools the server advertises, such as misspellings.
+func warnUnknownTools(diags *diag.Diagnostics, attribute string, entries, tools []string) {
+	advertised := make(map[string]struct{}, len(tools))
+	for _, tool := range tools {
+		advertised[tool] = struct{}{}
+	}
+	sorted := slices.Sorted(maps.Keys(advertised))
+	toolList := "none"
+	if len(sorted) > 0 {
+		toolList = strings.Join(sorted, ", ")
+	}
+	for _, entry := range entries {
+		if _, ok := advertised[entry]; ok {
+			continue
+		}
+		diags.AddAttributeWarning(path.Root(attribute), "Unknown MCP Tool",
+			fmt.Sprintf("The MCP server doesn't advertise a tool named %q, so this `%s` entry matches nothing. Advertised tools: %s.", entry, attribute, toolList))
 	}
 }
 
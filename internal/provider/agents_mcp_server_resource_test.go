@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
@@ -116,6 +117,19 @@ resource "terraform_data" "endpoint" {
   oauth2_token_url = "https://issuer.example.com/token"`,
 			wantError: "Invalid Attribute Value",
 		},
+		"api key missing value with unknown verify": {
+			// An unknown verify only defers the check, not the credential
+			// checks.
+			body: `  auth_type      = "api_key"
+  api_key_header = "Authorization"
+  verify         = terraform_data.verify.output`,
+			extra: `
+resource "terraform_data" "verify" {
+  input = { timeout = "5s" }
+}
+`,
+			wantError: "Missing API Key Value",
+		},
 		"oauth2 empty token url": {
 			body: `  auth_type        = "oauth2"
   oauth2_client_id = "client-id"
@@ -375,6 +389,8 @@ func TestAgentsMCPServerStateFromServer(t *testing.T) {
 	updatedAt := time.Unix(200, 0)
 	prior := testAgentsMCPServerModel("api_key")
 	prior.APIKeyValueWOVersion = types.Int64Value(7)
+	prior.LastVerifiedAt = types.Int64Value(300)
+	prior.Tools = stringSetValue([]string{"search"})
 	state := prior.stateFromServer(codersdk.MCPServerConfig{
 		ID:                  id,
 		OrganizationID:      organizationID,
@@ -408,6 +424,105 @@ func TestAgentsMCPServerStateFromServer(t *testing.T) {
 	require.Equal(t, int64(100), state.CreatedAt.ValueInt64())
 	require.Equal(t, int64(200), state.UpdatedAt.ValueInt64())
 	require.Len(t, state.ToolAllowList.Elements(), 1)
+	// Verification results live only in Terraform.
+	require.Equal(t, prior.LastVerifiedAt, state.LastVerifiedAt)
+	require.Equal(t, prior.Tools, state.Tools)
+	// An imported server discovers its tools, as the default does.
+	require.True(t, AgentsMCPServerResourceModel{}.stateFromServer(codersdk.MCPServerConfig{}).DiscoverTools.ValueBool())
+}
+
+func TestAgentsMCPServerWarnUnknownTools(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	warnUnknownTools(&diags, "tool_allow_list", []string{"search", "serach"}, []string{"search", "read"})
+	require.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	require.Equal(t, "Unknown MCP Tool", diags[0].Summary())
+	require.Contains(t, diags[0].Detail(), `"serach"`)
+	require.Contains(t, diags[0].Detail(), "Advertised tools: read, search.")
+
+	diags = nil
+	warnUnknownTools(&diags, "tool_deny_list", []string{"delete"}, nil)
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Detail(), "Advertised tools: none.")
+}
+
+func TestAgentsMCPServerAdvertisedTools(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(fakeMCPServer{tools: []string{"search", "read"}, apiKey: "secret"}.streamableHTTP())
+	t.Cleanup(srv.Close)
+	r := &AgentsMCPServerResource{}
+
+	plan := testAgentsMCPServerModel("api_key")
+	plan.URL = types.StringValue(srv.URL)
+	plan.APIKeyHeader = types.StringValue("X-API-Key")
+	config := plan
+	config.APIKeyValueWO = types.StringValue("secret")
+	last := plan
+	last.Tools = stringSetValue([]string{"search"})
+
+	// Changing a tool list makes the plan discover the tools again.
+	changedLists := plan
+	changedLists.ToolAllowList = stringSetValue([]string{"read"})
+
+	t.Run("Discovered", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		tools, ok := r.advertisedTools(context.Background(), changedLists, config, last, &diags)
+		require.False(t, diags.HasError())
+		require.True(t, ok)
+		require.ElementsMatch(t, []string{"search", "read"}, tools)
+	})
+
+	t.Run("DiscoveryDisabled", func(t *testing.T) {
+		t.Parallel()
+		// Without discover_tools, the plan doesn't contact the server.
+		plan := changedLists
+		plan.DiscoverTools = types.BoolValue(false)
+		var diags diag.Diagnostics
+		tools, ok := r.advertisedTools(context.Background(), plan, config, last, &diags)
+		require.True(t, ok)
+		require.Equal(t, []string{"search"}, tools)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		t.Parallel()
+		// A plan that changes nothing the tools depend on reuses the last
+		// discovery's tools.
+		var diags diag.Diagnostics
+		tools, ok := r.advertisedTools(context.Background(), plan, config, last, &diags)
+		require.True(t, ok)
+		require.Equal(t, []string{"search"}, tools)
+	})
+
+	t.Run("DiscoveryFailsFallsBack", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		tools, ok := r.advertisedTools(context.Background(), changedLists, changedLists, last, &diags)
+		require.True(t, ok)
+		require.Equal(t, []string{"search"}, tools)
+	})
+
+	t.Run("URLChanged", func(t *testing.T) {
+		t.Parallel()
+		plan := plan
+		plan.URL = types.StringValue(srv.URL + "/v2")
+		plan.DiscoverTools = types.BoolValue(false)
+		var diags diag.Diagnostics
+		_, ok := r.advertisedTools(context.Background(), plan, config, last, &diags)
+		require.False(t, ok)
+	})
+
+	t.Run("NeverDiscovered", func(t *testing.T) {
+		t.Parallel()
+		plan := plan
+		plan.DiscoverTools = types.BoolValue(false)
+		var diags diag.Diagnostics
+		_, ok := r.advertisedTools(context.Background(), plan, config, AgentsMCPServerResourceModel{}, &diags)
+		require.False(t, ok)
+	})
 }
 
 func testAgentsMCPServerModel(authType string) AgentsMCPServerResourceModel {
@@ -438,6 +553,7 @@ func testAgentsMCPServerModel(authType string) AgentsMCPServerResourceModel {
 		ModelIntent:                 types.BoolValue(false),
 		AllowInPlanMode:             types.BoolValue(false),
 		ForwardCoderHeaders:         types.BoolValue(false),
+		DiscoverTools:               types.BoolValue(true),
 	}
 }
 
@@ -449,20 +565,7 @@ func TestAccAgentsMCPServerResource(t *testing.T) {
 
 	ctx := t.Context()
 	client := integration.StartCoder(ctx, t, "agents_mcp_server_acc")
-	organizations, err := client.Organizations(ctx)
-	require.NoError(t, err, "list organizations")
-	require.NotEmpty(t, organizations, "first user must belong to an organization")
-
-	// Main devel builds report the previous minor's version, so a semver minimum
-	// cannot distinguish them from releases that do not have this route.
-	_, err = client.MCPServerConfigs(ctx, organizations[0].ID)
-	if err != nil {
-		var sdkErr *codersdk.Error
-		if errors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusNotFound {
-			t.Skipf("deployment does not support org-scoped MCP server configs")
-		}
-		require.NoError(t, err, "probe org-scoped MCP server configs")
-	}
+	skipWithoutAgentsMCPServerConfigs(ctx, t, client)
 
 	minimal := testAccAgentsMCPServerResourceConfig{
 		URL:         client.URL.String(),
@@ -715,6 +818,112 @@ resource "terraform_data" "nullendpoint" {
 	})
 }
 
+// skipWithoutAgentsMCPServerConfigs skips the test if the deployment lacks
+// the org-scoped MCP server API.
+func skipWithoutAgentsMCPServerConfigs(ctx context.Context, t *testing.T, client *codersdk.Client) {
+	t.Helper()
+	organizations, err := client.Organizations(ctx)
+	require.NoError(t, err, "list organizations")
+	require.NotEmpty(t, organizations, "first user must belong to an organization")
+
+	// Main devel builds report the previous minor's version, so a semver minimum
+	// cannot distinguish them from releases that do not have this route.
+	_, err = client.MCPServerConfigs(ctx, organizations[0].ID)
+	if err != nil {
+		var sdkErr *codersdk.Error
+		if errors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusNotFound {
+			t.Skipf("deployment does not support org-scoped MCP server configs")
+		}
+		require.NoError(t, err, "probe org-scoped MCP server configs")
+	}
+}
+
+func TestAccAgentsMCPServerResourceVerify(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "agents_mcp_server_verify_acc")
+	skipWithoutAgentsMCPServerConfigs(ctx, t, client)
+
+	// The check runs from the provider, so a local fake server works.
+	mcpServer := httptest.NewServer(fakeMCPServer{tools: []string{"search", "read", "delete"}, apiKey: "secret-one"}.streamableHTTP())
+	t.Cleanup(mcpServer.Close)
+
+	verified := testAccAgentsMCPServerResourceConfig{
+		URL:                client.URL.String(),
+		Token:              client.SessionToken(),
+		DisplayName:        "MCP Verify",
+		Slug:               "mcp-verify",
+		ServerURL:          mcpServer.URL,
+		AuthType:           "api_key",
+		APIKeyHeader:       "X-API-Key",
+		APIKeyValue:        "secret-one",
+		APIKeyValueVersion: 1,
+		RawConfig:          "  verify = {}",
+	}
+
+	rejected := verified
+	rejected.APIKeyValue = "secret-two"
+	rejected.APIKeyValueVersion = 2
+	rejected.RawConfig = "  verify = { fail_on_error = true }"
+
+	unverified := rejected
+	unverified.RawConfig = ""
+
+	undiscovered := verified
+	undiscovered.RawConfig = "  discover_tools = false"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		TerraformVersionChecks:   testAgentsMCPServerTerraformVersionChecks(),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: verified.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderd_agents_mcp_server.test", "last_verified_at"),
+					resource.TestCheckResourceAttr("coderd_agents_mcp_server.test", "tools.#", "3"),
+					resource.TestCheckTypeSetElemAttr("coderd_agents_mcp_server.test", "tools.*", "search"),
+				),
+			},
+			{
+				Config:      rejected.String(t),
+				ExpectError: regexp.MustCompile("MCP Server Verification Failed"),
+			},
+			{
+				// The failed check kept the results of the one that passed.
+				Config: rejected.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("coderd_agents_mcp_server.test", "tools.#", "3"),
+			},
+			{
+				// Discovery still runs without verify. It fails with the
+				// rejected key, so the tools are kept.
+				Config: unverified.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderd_agents_mcp_server.test", "last_verified_at"),
+					resource.TestCheckResourceAttr("coderd_agents_mcp_server.test", "tools.#", "3"),
+				),
+			},
+			{
+				Config: undiscovered.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderd_agents_mcp_server.test", "last_verified_at"),
+					resource.TestCheckNoResourceAttr("coderd_agents_mcp_server.test", "tools.#"),
+				),
+			},
+		},
+	})
+}
+
 type testAccAgentsMCPServerResourceConfig struct {
 	URL                string
 	Token              string
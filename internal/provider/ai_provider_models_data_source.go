@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AIProviderModelsDataSource{}
+
+func NewAIProviderModelsDataSource() datasource.DataSource {
+	return &AIProviderModelsDataSource{}
+}
+
+// AIProviderModelsDataSource defines the data source implementation.
+type AIProviderModelsDataSource struct {
+	data *CoderdProviderData
+}
+
+func (d *AIProviderModelsDataSource) experimentalClient() *codersdk.ExperimentalClient {
+	return codersdk.NewExperimentalClient(d.data.Client)
+}
+
+// AIProviderModelsDataSourceModel describes the data source data model.
+type AIProviderModelsDataSourceModel struct {
+	AIProviderID UUID `tfsdk:"ai_provider_id"`
+
+	ProviderType      types.String      `tfsdk:"provider_type"`
+	Available         types.Bool        `tfsdk:"available"`
+	UnavailableReason types.String      `tfsdk:"unavailable_reason"`
+	Models            []AIProviderModel `tfsdk:"models"`
+}
+
+type AIProviderModel struct {
+	Model             types.String `tfsdk:"model"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	ContextLimit      types.Int64  `tfsdk:"context_limit"`
+	MaxOutputTokens   types.Int64  `tfsdk:"max_output_tokens"`
+	SupportsTools     types.Bool   `tfsdk:"supports_tools"`
+	SupportsReasoning types.Bool   `tfsdk:"supports_reasoning"`
+	SupportsImages    types.Bool   `tfsdk:"supports_images"`
+}
+
+func (d *AIProviderModelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ai_provider_models"
+}
+
+func (d *AIProviderModelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "~> This data source is experimental. Changes are expected, and it is not recommended for production use.\n\n" +
+			"The models Coder Agents can use through a `coderd_ai_provider`, as listed in the deployment's model catalog for the provider's type. " +
+			"The catalog is per type, not per provider: it doesn't query the provider's API, so it lists the same models for every provider of a type, " +
+			"whatever models the provider's key or base URL can actually reach. " +
+			"Use it to look up the `display_name` and `context_limit` of `coderd_agents_model` resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"ai_provider_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the AI provider. Usually this is `coderd_ai_provider.<name>.id`.",
+				CustomType:          UUIDType,
+				Required:            true,
+			},
+			"provider_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the AI provider, for example `openai`, `anthropic`, or `bedrock`.",
+				Computed:            true,
+			},
+			"available": schema.BoolAttribute{
+				MarkdownDescription: "Whether Coder can currently use the provider's models.",
+				Computed:            true,
+			},
+			"unavailable_reason": schema.StringAttribute{
+				MarkdownDescription: "Why Coder can't use the provider's models, such as a missing API key. Empty if `available` is true.",
+				Computed:            true,
+			},
+			"models": schema.ListNestedAttribute{
+				MarkdownDescription: "The models in the catalog for the provider's type. Listed even if the provider isn't `available`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"model": schema.StringAttribute{
+							MarkdownDescription: "The model identifier, for `coderd_agents_model.model`.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"context_limit": schema.Int64Attribute{
+							MarkdownDescription: "The model's context window, in tokens. Null if the catalog doesn't know it.",
+							Computed:            true,
+						},
+						"max_output_tokens": schema.Int64Attribute{
+							MarkdownDescription: "The most tokens the model can output in one response. Null if the catalog doesn't know it.",
+							Computed:            true,
+						},
+						"supports_tools": schema.BoolAttribute{
+							MarkdownDescription: "Whether the model can call tools.",
+							Computed:            true,
+						},
+						"supports_reasoning": schema.BoolAttribute{
+							MarkdownDescription: "Whether the model can reason before responding.",
+							Computed:            true,
+						},
+						"supports_images": schema.BoolAttribute{
+							MarkdownDescription: "Whether the model accepts images as input.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AIProviderModelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *AIProviderModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AIProviderModelsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Experimental Data Source",
+		"coderd_ai_provider_models is experimental. Changes are expected, and it is not recommended for production use.",
+	)
+
+	provider, err := d.data.Client.AIProvider(ctx, data.AIProviderID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read AI provider %s, got error: %s", data.AIProviderID.ValueString(), err))
+		return
+	}
+	catalog, err := d.experimentalClient().ListChatModels(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list chat models, got error: %s", err))
+		return
+	}
+
+	data.ProviderType = types.StringValue(string(provider.Type))
+	data.setCatalog(catalog, provider.Type)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setCatalog fills in the model from the catalog entry for providerType. A
+// type missing from the catalog has no models and is unavailable.
+func (m *AIProviderModelsDataSourceModel) setCatalog(catalog codersdk.ChatModelsResponse, providerType codersdk.AIProviderType) {
+	m.Available = types.BoolValue(false)
+	m.UnavailableReason = types.StringValue(fmt.Sprintf("the model catalog has no %s provider", providerType))
+	m.Models = []AIProviderModel{}
+	for _, entry := range catalog.Providers {
+		if entry.Provider != string(providerType) {
+			continue
+		}
+		m.Available = types.BoolValue(entry.Available)
+		m.UnavailableReason = types.StringValue(string(entry.UnavailableReason))
+		for _, model := range entry.Models {
+			m.Models = append(m.Models, AIProviderModel{
+				Model:             types.StringValue(model.Model),
+				DisplayName:       types.StringValue(model.DisplayName),
+				ContextLimit:      int64ValueOrNull(model.ContextLimit),
+				MaxOutputTokens:   int64ValueOrNull(model.MaxOutputTokens),
+				SupportsTools:     types.BoolValue(model.SupportsTools),
+				SupportsReasoning: types.BoolValue(model.SupportsReasoning),
+				SupportsImages:    types.BoolValue(model.SupportsImages),
+			})
+		}
+		return
+	}
+}
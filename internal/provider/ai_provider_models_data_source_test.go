@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIProviderModelsSetCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := codersdk.ChatModelsResponse{
+		Providers: []codersdk.ChatModelProvider{
+			{
+				Provider:          string(codersdk.AIProviderTypeAnthropic),
+				Available:         false,
+				UnavailableReason: "missing_api_key",
+				Models: []codersdk.ChatModel{
+					{Model: "claude-haiku", DisplayName: "Claude Haiku"},
+				},
+			},
+			{
+				Provider:  string(codersdk.AIProviderTypeOpenAI),
+				Available: true,
+				Models: []codersdk.ChatModel{
+					{
+						Model:           "gpt-large",
+						DisplayName:     "GPT Large",
+						ContextLimit:    400000,
+						MaxOutputTokens: 128000,
+						SupportsTools:   true,
+						SupportsImages:  true,
+					},
+					{Model: "gpt-custom", DisplayName: "GPT Custom"},
+				},
+			},
+		},
+	}
+
+	t.Run("Available", func(t *testing.T) {
+		t.Parallel()
+		var m AIProviderModelsDataSourceModel
+		m.setCatalog(catalog, codersdk.AIProviderTypeOpenAI)
+		require.True(t, m.Available.ValueBool())
+		require.Equal(t, "", m.UnavailableReason.ValueString())
+		require.Equal(t, []AIProviderModel{
+			{
+				Model:             types.StringValue("gpt-large"),
+				DisplayName:       types.StringValue("GPT Large"),
+				ContextLimit:      types.Int64Value(400000),
+				MaxOutputTokens:   types.Int64Value(128000),
+				SupportsTools:     types.BoolValue(true),
+				SupportsReasoning: types.BoolValue(false),
+				SupportsImages:    types.BoolValue(true),
+			},
+			{
+				Model:             types.StringValue("gpt-custom"),
+				DisplayName:       types.StringValue("GPT Custom"),
+				ContextLimit:      types.Int64Null(),
+				MaxOutputTokens:   types.Int64Null(),
+				SupportsTools:     types.BoolValue(false),
+				SupportsReasoning: types.BoolValue(false),
+				SupportsImages:    types.BoolValue(false),
+			},
+		}, m.Models)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		t.Parallel()
+		var m AIProviderModelsDataSourceModel
+		m.setCatalog(catalog, codersdk.AIProviderTypeAnthropic)
+		require.False(t, m.Available.ValueBool())
+		require.Equal(t, "missing_api_key", m.UnavailableReason.ValueString())
+		require.Len(t, m.Models, 1)
+	})
+
+	t.Run("NotInCatalog", func(t *testing.T) {
+		t.Parallel()
+		var m AIProviderModelsDataSourceModel
+		m.setCatalog(catalog, codersdk.AIProviderTypeBedrock)
+		require.False(t, m.Available.ValueBool())
+		require.Contains(t, m.UnavailableReason.ValueString(), "bedrock")
+		require.NotNil(t, m.Models)
+		require.Empty(t, m.Models)
+	})
+}
+
+func TestAccAIProviderModelsDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "ai_provider_models_data_acc", integration.UseLicense)
+
+	cfg := fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_ai_provider" "openai" {
+	type               = "openai"
+	name               = "openai-models-acc"
+	base_url           = "https://api.openai.com/v1"
+	api_key_wo         = "sk-test-000000"
+	api_key_wo_version = 1
+}
+
+data "coderd_ai_provider_models" "openai" {
+	ai_provider_id = coderd_ai_provider.openai.id
+}
+`, client.URL.String(), client.SessionToken())
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		TerraformVersionChecks:   testAIProviderTerraformVersionChecks(),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_ai_provider_models.openai", "provider_type", "openai"),
+					resource.TestCheckResourceAttrSet("data.coderd_ai_provider_models.openai", "available"),
+					resource.TestCheckResourceAttrSet("data.coderd_ai_provider_models.openai", "models.#"),
+				),
+			},
+		},
+	})
+}
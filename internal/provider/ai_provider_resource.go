@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -52,12 +56,17 @@ type AIProviderResourceModel struct {
 	APIKeyWOVersion types.Int64              `tfsdk:"api_key_wo_version"`
 	APIKeyMasked    types.String             `tfsdk:"api_key_masked"`
 	Settings        *AIProviderSettingsModel `tfsdk:"settings"`
+	Verify          types.Object             `tfsdk:"verify"`
+	LastVerifiedAt  types.Int64              `tfsdk:"last_verified_at"`
 	CreatedAt       types.Int64              `tfsdk:"created_at"`
 	UpdatedAt       types.Int64              `tfsdk:"updated_at"`
 }
 
 type AIProviderSettingsModel struct {
-	Bedrock *AIProviderBedrockSettingsModel `tfsdk:"bedrock"`
+	Bedrock          *AIProviderBedrockSettingsModel          `tfsdk:"bedrock"`
+	AzureOpenAI      *AIProviderAzureOpenAISettingsModel      `tfsdk:"azure_openai"`
+	Vertex           *AIProviderVertexSettingsModel           `tfsdk:"vertex"`
+	OpenAICompatible *AIProviderOpenAICompatibleSettingsModel `tfsdk:"openai_compatible"`
 }
 
 type AIProviderBedrockSettingsModel struct {
@@ -72,6 +81,27 @@ type AIProviderBedrockSettingsModel struct {
 	Protocol             types.String `tfsdk:"protocol"`
 }
 
+type AIProviderAzureOpenAISettingsModel struct {
+	Deployment           types.String `tfsdk:"deployment"`
+	APIVersion           types.String `tfsdk:"api_version"`
+	TenantID             types.String `tfsdk:"tenant_id"`
+	ClientID             types.String `tfsdk:"client_id"`
+	ClientSecretWO       types.String `tfsdk:"client_secret_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type AIProviderVertexSettingsModel struct {
+	Project              types.String `tfsdk:"project"`
+	Location             types.String `tfsdk:"location"`
+	CredentialsJSONWO    types.String `tfsdk:"credentials_json_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type AIProviderOpenAICompatibleSettingsModel struct {
+	HeadersWO        types.Map   `tfsdk:"headers_wo"`
+	HeadersWOVersion types.Int64 `tfsdk:"headers_wo_version"`
+}
+
 func (r *AIProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_ai_provider"
 }
@@ -148,7 +178,7 @@ func (r *AIProviderResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Required:            true,
 			},
 			"api_key_wo": schema.StringAttribute{
-				MarkdownDescription: "Plaintext API key for the provider. Not valid for `bedrock` or `copilot`, or when `settings.bedrock` is set. Bump `api_key_wo_version` to rotate it.",
+				MarkdownDescription: "Plaintext API key for the provider. Not valid for `bedrock` or `copilot`, or when `settings.bedrock` or `settings.vertex` is set. Bump `api_key_wo_version` to rotate it.",
 				Optional:            true,
 				Sensitive:           true,
 				WriteOnly:           true,
@@ -166,7 +196,7 @@ func (r *AIProviderResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 			},
 			"settings": schema.SingleNestedAttribute{
-				MarkdownDescription: "Type-specific provider settings.",
+				MarkdownDescription: "Type-specific provider settings. Configure at most one block.",
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"bedrock": schema.SingleNestedAttribute{
@@ -263,8 +293,122 @@ func (r *AIProviderResource) Schema(ctx context.Context, req resource.SchemaRequ
 							},
 						},
 					},
+					"azure_openai": schema.SingleNestedAttribute{
+						MarkdownDescription: "Azure OpenAI settings. Valid only for `type = \"azure\"`. Authenticate with `api_key_wo`, or with a Microsoft Entra ID service principal by setting `tenant_id`, `client_id`, and `client_secret_wo`.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"deployment": schema.StringAttribute{
+								MarkdownDescription: "Name of the Azure OpenAI deployment to send requests to. Omit to use the model requested by the client, as the Azure OpenAI v1 API does.",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"api_version": schema.StringAttribute{
+								MarkdownDescription: "Azure OpenAI API version, such as `2024-10-21`. Omit to use the v1 API, which takes no version.",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"tenant_id": schema.StringAttribute{
+								MarkdownDescription: "Microsoft Entra ID tenant of the service principal.",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"client_id": schema.StringAttribute{
+								MarkdownDescription: "Application (client) ID of the service principal.",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"client_secret_wo": schema.StringAttribute{
+								MarkdownDescription: "Client secret of the service principal.",
+								Optional:            true,
+								Sensitive:           true,
+								WriteOnly:           true,
+								Validators: []validator.String{
+									stringvalidator.AlsoRequires(
+										path.MatchRoot("settings").AtName("azure_openai").AtName("tenant_id"),
+										path.MatchRoot("settings").AtName("azure_openai").AtName("client_id"),
+										path.MatchRoot("settings").AtName("azure_openai").AtName("credentials_wo_version"),
+									),
+								},
+							},
+							"credentials_wo_version": schema.Int64Attribute{
+								MarkdownDescription: "Version for the write-only client secret. Bump this value to send, rotate, or clear it.",
+								Optional:            true,
+							},
+						},
+					},
+					"vertex": schema.SingleNestedAttribute{
+						MarkdownDescription: "Google Vertex AI settings. Valid only for `type = \"google\"` or `type = \"anthropic\"`. Omit `credentials_json_wo` to use the Google Application Default Credentials of the Coder server process (attached service account, workload identity, `GOOGLE_APPLICATION_CREDENTIALS`, and more).",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"project": schema.StringAttribute{
+								MarkdownDescription: "Google Cloud project ID that Vertex AI requests are billed to.",
+								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"location": schema.StringAttribute{
+								MarkdownDescription: "Vertex AI location, such as `us-central1` or `global`.",
+								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"credentials_json_wo": schema.StringAttribute{
+								MarkdownDescription: "Google service account key, as JSON.",
+								Optional:            true,
+								Sensitive:           true,
+								WriteOnly:           true,
+								Validators: []validator.String{
+									stringvalidator.AlsoRequires(
+										path.MatchRoot("settings").AtName("vertex").AtName("credentials_wo_version"),
+									),
+								},
+							},
+							"credentials_wo_version": schema.Int64Attribute{
+								MarkdownDescription: "Version for the write-only service account key. Bump this value to send, rotate, or clear it.",
+								Optional:            true,
+							},
+						},
+					},
+					"openai_compatible": schema.SingleNestedAttribute{
+						MarkdownDescription: "OpenAI-compatible gateway settings. Valid only for `type = \"openai-compat\"`.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"headers_wo": schema.MapAttribute{
+								MarkdownDescription: "HTTP headers Coder adds to every request to the gateway, such as a gateway's own authentication header alongside `api_key_wo`.",
+								ElementType:         types.StringType,
+								Optional:            true,
+								Sensitive:           true,
+								WriteOnly:           true,
+								Validators: []validator.Map{
+									mapvalidator.AlsoRequires(
+										path.MatchRoot("settings").AtName("openai_compatible").AtName("headers_wo_version"),
+									),
+								},
+							},
+							"headers_wo_version": schema.Int64Attribute{
+								MarkdownDescription: "Version for the write-only headers. Bump this value to send, replace, or clear them.",
+								Optional:            true,
+							},
+						},
+					},
 				},
 			},
+			"verify": verifySchema("the provider's API accepts `api_key_wo` by listing its models. " +
+				"Without `api_key_wo` in the configuration, and for `bedrock` and `copilot` providers, which Coder doesn't authenticate with an API key, the check only confirms that `base_url` responds"),
+			"last_verified_at": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp of the last `verify` check that passed. Null if `verify` is omitted or no check has passed.",
+				Computed:            true,
+			},
 			"created_at": schema.Int64Attribute{
 				MarkdownDescription: "Creation timestamp as Unix seconds.",
 				Computed:            true,
@@ -307,13 +451,15 @@ func (r *AIProviderResource) ValidateConfig(ctx context.Context, req resource.Va
 		return
 	}
 	if !settings.IsNull() {
-		var bedrock types.Object
-		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("settings").AtName("bedrock"), &bedrock)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		if bedrock.IsUnknown() {
-			return
+		for _, name := range aiProviderSettingsBlocks {
+			var block types.Object
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("settings").AtName(name), &block)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if block.IsUnknown() {
+				return
+			}
 		}
 	}
 
@@ -353,7 +499,33 @@ func (r *AIProviderResource) ValidateConfig(ctx context.Context, req resource.Va
 		case data.bedrock() != nil:
 			// The server rejects api_keys whenever settings.bedrock is set.
 			resp.Diagnostics.AddAttributeError(path.Root("api_key_wo"), "Invalid Attribute Combination", "`api_key_wo` must not be configured when `settings.bedrock` is set; Bedrock-backed providers authenticate via `settings.bedrock`.")
+		case data.vertex() != nil:
+			resp.Diagnostics.AddAttributeError(path.Root("api_key_wo"), "Invalid Attribute Combination", "`api_key_wo` must not be configured when `settings.vertex` is set; Vertex AI providers authenticate with Google credentials.")
+		case data.azureOpenAI() != nil && !data.azureOpenAI().ClientSecretWO.IsNull():
+			resp.Diagnostics.AddAttributeError(path.Root("api_key_wo"), "Invalid Attribute Combination", "Configure either `api_key_wo` or `settings.azure_openai.client_secret_wo`, not both.")
+		}
+	}
+
+	if data.Settings != nil {
+		if blocks := data.Settings.blocks(); len(blocks) > 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Settings", fmt.Sprintf("`settings` must include only one block, got `%s`.", strings.Join(blocks, "`, `")))
+		}
+	}
+	if data.azureOpenAI() != nil && providerType != codersdk.AIProviderTypeAzure {
+		resp.Diagnostics.AddAttributeError(path.Root("settings").AtName("azure_openai"), "Invalid Attribute Combination", "`settings.azure_openai` is only valid when `type` is `azure`.")
+	}
+	if vertex := data.vertex(); vertex != nil {
+		if providerType != codersdk.AIProviderTypeGoogle && providerType != codersdk.AIProviderTypeAnthropic {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtName("vertex"), "Invalid Attribute Combination", "`settings.vertex` is only valid when `type` is `google` or `anthropic`.")
 		}
+		// An empty key clears stored credentials, so only a non-empty one
+		// has to be JSON.
+		if key := vertex.CredentialsJSONWO; !key.IsNull() && !key.IsUnknown() && key.ValueString() != "" && !json.Valid([]byte(key.ValueString())) {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtName("vertex").AtName("credentials_json_wo"), "Invalid Vertex Credentials", "`credentials_json_wo` must be a Google service account key in JSON format.")
+		}
+	}
+	if data.openAICompatible() != nil && providerType != codersdk.AIProviderTypeOpenAICompat {
+		resp.Diagnostics.AddAttributeError(path.Root("settings").AtName("openai_compatible"), "Invalid Attribute Combination", "`settings.openai_compatible` is only valid when `type` is `openai-compat`.")
 	}
 
 	bedrock := data.bedrock()
@@ -361,9 +533,9 @@ func (r *AIProviderResource) ValidateConfig(ctx context.Context, req resource.Va
 		switch {
 		case providerType == codersdk.AIProviderTypeBedrock:
 			resp.Diagnostics.AddAttributeError(path.Root("settings"), "Missing Bedrock Settings", "`type = \"bedrock\"` requires `settings.bedrock` with at least `region` or write-only AWS credentials.")
-		case data.Settings != nil:
+		case data.Settings != nil && len(data.Settings.blocks()) == 0:
 			// An empty settings = {} produces a null-vs-empty diff; reject it.
-			resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Settings", "`settings` must include a `bedrock` block or be omitted.")
+			resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Settings", "`settings` must include a `bedrock`, `azure_openai`, `vertex`, or `openai_compatible` block, or be omitted.")
 		}
 		return
 	}
@@ -422,11 +594,13 @@ func (r *AIProviderResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 	checkBedrockRoleARNDropped(config, provider, &resp.Diagnostics)
 	checkBedrockProtocolDropped(config, provider, &resp.Diagnostics)
+	checkSettingsBlocksDropped(config, provider, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	state := plan.stateFromProvider(provider)
+	r.setVerification(ctx, &state, types.Int64Null(), config, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -482,6 +656,7 @@ func (r *AIProviderResource) Update(ctx context.Context, req resource.UpdateRequ
 			return
 		}
 		refreshed := plan.stateFromProvider(provider)
+		r.setVerification(ctx, &refreshed, state.LastVerifiedAt, config, &resp.Diagnostics)
 		resp.Diagnostics.Append(resp.State.Set(ctx, &refreshed)...)
 		return
 	}
@@ -498,10 +673,12 @@ func (r *AIProviderResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 	checkBedrockRoleARNDropped(config, provider, &resp.Diagnostics)
 	checkBedrockProtocolDropped(config, provider, &resp.Diagnostics)
+	checkSettingsBlocksDropped(config, provider, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	updated := plan.stateFromProvider(provider)
+	r.setVerification(ctx, &updated, state.LastVerifiedAt, config, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updated)...)
 }
 
@@ -542,7 +719,12 @@ func (m AIProviderResourceModel) createRequest(config AIProviderResourceModel, d
 		Enabled:     m.Enabled.ValueBool(),
 		BaseURL:     m.BaseURL.ValueString(),
 		APIKeys:     apiKeys,
-		Settings:    m.sdkSettings(config, bedrockCredentialsConfigured(config.bedrock()), diags),
+		Settings: m.sdkSettings(config, settingsCredentials{
+			bedrock:          bedrockCredentialsConfigured(config.bedrock()),
+			azureOpenAI:      config.azureOpenAI() != nil && !config.azureOpenAI().ClientSecretWO.IsNull(),
+			vertex:           config.vertex() != nil && !config.vertex().CredentialsJSONWO.IsNull(),
+			openAICompatible: config.openAICompatible() != nil && !config.openAICompatible().HeadersWO.IsNull(),
+		}, diags),
 	}
 }
 
@@ -562,11 +744,15 @@ func (m AIProviderResourceModel) updateRequest(state, config AIProviderResourceM
 	}
 
 	// Send settings whenever they are (or were) present. The server merges
-	// credentials, so omitting credential pointers leaves stored AWS keys
-	// untouched; dropping the bedrock block clears settings server-side.
-	credentialsChanged := credentialsVersionChanged(m.bedrock(), state.bedrock())
-	if m.bedrock() != nil || state.bedrock() != nil {
-		settings := m.sdkSettings(config, credentialsChanged, diags)
+	// credentials, so omitting credential pointers leaves stored keys
+	// untouched; dropping a settings block clears it server-side.
+	if m.Settings != nil || state.Settings != nil {
+		settings := m.sdkSettings(config, settingsCredentials{
+			bedrock:          credentialsVersionChanged(m.bedrock(), state.bedrock()),
+			azureOpenAI:      writeOnlyVersionChanged(m.azureOpenAI().credentialsVersion(), state.azureOpenAI().credentialsVersion()),
+			vertex:           writeOnlyVersionChanged(m.vertex().credentialsVersion(), state.vertex().credentialsVersion()),
+			openAICompatible: writeOnlyVersionChanged(m.openAICompatible().headersVersion(), state.openAICompatible().headersVersion()),
+		}, diags)
 		patch.Settings = &settings
 	}
 
@@ -612,11 +798,68 @@ func (m AIProviderResourceModel) validateEffectiveUpdateState(state, config AIPr
 	}
 }
 
-func (m AIProviderResourceModel) sdkSettings(config AIProviderResourceModel, includeCredentials bool, diags *diag.Diagnostics) codersdk.AIProviderSettings {
-	bedrock := m.bedrock()
-	if bedrock == nil {
-		return codersdk.AIProviderSettings{}
+// settingsCredentials selects the settings blocks whose write-only
+// credentials are sent to Coder.
+type settingsCredentials struct {
+	bedrock          bool
+	azureOpenAI      bool
+	vertex           bool
+	openAICompatible bool
+}
+
+func (m AIProviderResourceModel) sdkSettings(config AIProviderResourceModel, includeCredentials settingsCredentials, diags *diag.Diagnostics) codersdk.AIProviderSettings {
+	var settings codersdk.AIProviderSettings
+	if m.bedrock() != nil {
+		settings.Bedrock = m.sdkBedrockSettings(config, includeCredentials.bedrock, diags)
+	}
+	if azure := m.azureOpenAI(); azure != nil {
+		settings.AzureOpenAI = &codersdk.AIProviderAzureOpenAISettings{
+			Deployment: azure.Deployment.ValueString(),
+			APIVersion: azure.APIVersion.ValueString(),
+			TenantID:   azure.TenantID.ValueString(),
+			ClientID:   azure.ClientID.ValueString(),
+		}
+		if includeCredentials.azureOpenAI {
+			cfgAzure := config.azureOpenAI()
+			if cfgAzure == nil || cfgAzure.ClientSecretWO.IsNull() || cfgAzure.ClientSecretWO.IsUnknown() {
+				diags.AddAttributeError(path.Root("settings").AtName("azure_openai"), "Missing Azure OpenAI Credentials", "Azure OpenAI credential version changed, so `client_secret_wo` must be configured. Use an empty string to clear the stored secret.")
+			} else {
+				settings.AzureOpenAI.ClientSecret = stringPtrOrNil(cfgAzure.ClientSecretWO)
+			}
+		}
 	}
+	if vertex := m.vertex(); vertex != nil {
+		settings.Vertex = &codersdk.AIProviderVertexSettings{
+			Project:  vertex.Project.ValueString(),
+			Location: vertex.Location.ValueString(),
+		}
+		if includeCredentials.vertex {
+			cfgVertex := config.vertex()
+			if cfgVertex == nil || cfgVertex.CredentialsJSONWO.IsNull() || cfgVertex.CredentialsJSONWO.IsUnknown() {
+				diags.AddAttributeError(path.Root("settings").AtName("vertex"), "Missing Vertex Credentials", "Vertex credential version changed, so `credentials_json_wo` must be configured. Use an empty string to clear the stored key and fall back to Application Default Credentials.")
+			} else {
+				settings.Vertex.CredentialsJSON = stringPtrOrNil(cfgVertex.CredentialsJSONWO)
+			}
+		}
+	}
+	if m.openAICompatible() != nil {
+		settings.OpenAICompatible = &codersdk.AIProviderOpenAICompatibleSettings{}
+		if includeCredentials.openAICompatible {
+			cfgCompat := config.openAICompatible()
+			if cfgCompat == nil || cfgCompat.HeadersWO.IsNull() || cfgCompat.HeadersWO.IsUnknown() {
+				diags.AddAttributeError(path.Root("settings").AtName("openai_compatible"), "Missing Headers", "Header version changed, so `headers_wo` must be configured. Use an empty map to clear the stored headers.")
+			} else {
+				headers := map[string]string{}
+				diags.Append(cfgCompat.HeadersWO.ElementsAs(context.Background(), &headers, false)...)
+				settings.OpenAICompatible.Headers = headers
+			}
+		}
+	}
+	return settings
+}
+
+func (m AIProviderResourceModel) sdkBedrockSettings(config AIProviderResourceModel, includeCredentials bool, diags *diag.Diagnostics) *codersdk.AIProviderBedrockSettings {
+	bedrock := m.bedrock()
 	cfgBedrock := config.bedrock()
 	cfgRegion := bedrock.Region
 	if cfgBedrock != nil {
@@ -632,12 +875,12 @@ func (m AIProviderResourceModel) sdkSettings(config AIProviderResourceModel, inc
 	if includeCredentials {
 		if cfgBedrock == nil || cfgBedrock.AccessKeyWO.IsNull() || cfgBedrock.AccessKeyWO.IsUnknown() || cfgBedrock.AccessKeySecretWO.IsNull() || cfgBedrock.AccessKeySecretWO.IsUnknown() {
 			diags.AddAttributeError(path.Root("settings").AtName("bedrock"), "Missing Bedrock Credentials", "Bedrock credential version changed, so both `access_key_wo` and `access_key_secret_wo` must be configured. Use empty strings for both to clear stored credentials.")
-			return codersdk.AIProviderSettings{}
+			return nil
 		}
 		settings.AccessKey = stringPtrOrNil(cfgBedrock.AccessKeyWO)
 		settings.AccessKeySecret = stringPtrOrNil(cfgBedrock.AccessKeySecretWO)
 	}
-	return codersdk.AIProviderSettings{Bedrock: &settings}
+	return &settings
 }
 
 func (m AIProviderResourceModel) stateFromProvider(provider codersdk.AIProvider) AIProviderResourceModel {
@@ -654,6 +897,9 @@ func (m AIProviderResourceModel) stateFromProvider(provider codersdk.AIProvider)
 		// Write-only value is not returned; version is Terraform-only.
 		APIKeyWO:        types.StringNull(),
 		APIKeyWOVersion: m.APIKeyWOVersion,
+		// Verification happens in Terraform, not Coder.
+		Verify:         verifyOrNull(m.Verify),
+		LastVerifiedAt: m.LastVerifiedAt,
 	}
 	// This resource manages a single key and replaces all keys on rotation, so
 	// len(APIKeys) is always 0 or 1; index 0 is the key we manage.
@@ -677,9 +923,65 @@ func (m AIProviderResourceModel) stateFromProvider(provider codersdk.AIProvider)
 			out.Settings.Bedrock.CredentialsWOVersion = types.Int64Null()
 		}
 	}
+	if azure := provider.Settings.AzureOpenAI; azure != nil {
+		if out.Settings == nil {
+			out.Settings = &AIProviderSettingsModel{}
+		}
+		out.Settings.AzureOpenAI = &AIProviderAzureOpenAISettingsModel{
+			Deployment:           stringValueOrNull(azure.Deployment),
+			APIVersion:           stringValueOrNull(azure.APIVersion),
+			TenantID:             stringValueOrNull(azure.TenantID),
+			ClientID:             stringValueOrNull(azure.ClientID),
+			ClientSecretWO:       types.StringNull(),
+			CredentialsWOVersion: m.azureOpenAI().credentialsVersion(),
+		}
+	}
+	if vertex := provider.Settings.Vertex; vertex != nil {
+		if out.Settings == nil {
+			out.Settings = &AIProviderSettingsModel{}
+		}
+		out.Settings.Vertex = &AIProviderVertexSettingsModel{
+			Project:              types.StringValue(vertex.Project),
+			Location:             types.StringValue(vertex.Location),
+			CredentialsJSONWO:    types.StringNull(),
+			CredentialsWOVersion: m.vertex().credentialsVersion(),
+		}
+	}
+	if provider.Settings.OpenAICompatible != nil {
+		if out.Settings == nil {
+			out.Settings = &AIProviderSettingsModel{}
+		}
+		out.Settings.OpenAICompatible = &AIProviderOpenAICompatibleSettingsModel{
+			HeadersWO:        types.MapNull(types.StringType),
+			HeadersWOVersion: m.openAICompatible().headersVersion(),
+		}
+	}
 	return out
 }
 
+// setVerification runs the `verify` check after an apply and sets
+// last_verified_at: null without `verify`, and otherwise the time of this
+// check, or prior if it fails.
+func (r *AIProviderResource) setVerification(ctx context.Context, state *AIProviderResourceModel, prior types.Int64, config AIProviderResourceModel, diags *diag.Diagnostics) {
+	verify := verifyModel(ctx, state.Verify, diags)
+	if verify == nil {
+		state.LastVerifiedAt = types.Int64Null()
+		return
+	}
+	state.LastVerifiedAt = prior
+
+	ctx, cancel := context.WithTimeout(ctx, verify.timeout())
+	defer cancel()
+	tflog.Info(ctx, "verifying AI provider", map[string]any{"base_url": state.BaseURL.ValueString()})
+	err := verifyAIProvider(ctx, &http.Client{}, codersdk.AIProviderType(state.Type.ValueString()), state.BaseURL.ValueString(), writeOnlyString(config.APIKeyWO))
+	if err != nil {
+		verify.reportFailure(diags, "AI Provider Verification Failed",
+			fmt.Sprintf("Unable to verify AI provider %q at %s: %s", state.Name.ValueString(), state.BaseURL.ValueString(), err))
+		return
+	}
+	state.LastVerifiedAt = types.Int64Value(time.Now().Unix())
+}
+
 // A Coder server older than v2.35.0 drops the unknown role_arn JSON key
 // (omitempty), so a configured value round-trips to null and surfaces as a
 // cryptic "inconsistent values for sensitive attribute" error (#387). Fail
@@ -723,6 +1025,27 @@ func checkBedrockProtocolDropped(config AIProviderResourceModel, provider coders
 	)
 }
 
+// A Coder server that predates a settings block drops it from the request, so
+// the block would round-trip to null and surface as an inconsistent result.
+// Fail loudly instead (mirrors checkBedrockRoleARNDropped).
+func checkSettingsBlocksDropped(config AIProviderResourceModel, provider codersdk.AIProvider, diags *diag.Diagnostics) {
+	dropped := map[string]bool{
+		"azure_openai":      config.azureOpenAI() != nil && provider.Settings.AzureOpenAI == nil,
+		"vertex":            config.vertex() != nil && provider.Settings.Vertex == nil,
+		"openai_compatible": config.openAICompatible() != nil && provider.Settings.OpenAICompatible == nil,
+	}
+	for _, name := range aiProviderSettingsBlocks {
+		if !dropped[name] {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("settings").AtName(name),
+			fmt.Sprintf("Settings block %s not supported by this Coder deployment", name),
+			fmt.Sprintf("The Coder server accepted the request but did not persist `settings.%s`, which means it predates support for it. Upgrade Coder, or remove `settings.%s`.", name, name),
+		)
+	}
+}
+
 // bedrockProtocol converts a framework protocol value into its SDK form,
 // mapping null/unknown to the zero value (which the server resolves to
 // invoke-model).
@@ -740,6 +1063,65 @@ func (m AIProviderResourceModel) bedrock() *AIProviderBedrockSettingsModel {
 	return m.Settings.Bedrock
 }
 
+func (m AIProviderResourceModel) azureOpenAI() *AIProviderAzureOpenAISettingsModel {
+	if m.Settings == nil {
+		return nil
+	}
+	return m.Settings.AzureOpenAI
+}
+
+func (m AIProviderResourceModel) vertex() *AIProviderVertexSettingsModel {
+	if m.Settings == nil {
+		return nil
+	}
+	return m.Settings.Vertex
+}
+
+func (m AIProviderResourceModel) openAICompatible() *AIProviderOpenAICompatibleSettingsModel {
+	if m.Settings == nil {
+		return nil
+	}
+	return m.Settings.OpenAICompatible
+}
+
+// aiProviderSettingsBlocks are the attribute names of the settings blocks.
+var aiProviderSettingsBlocks = []string{"bedrock", "azure_openai", "vertex", "openai_compatible"}
+
+// blocks returns the names of the configured settings blocks.
+func (s *AIProviderSettingsModel) blocks() []string {
+	var blocks []string
+	for i, configured := range []bool{s.Bedrock != nil, s.AzureOpenAI != nil, s.Vertex != nil, s.OpenAICompatible != nil} {
+		if configured {
+			blocks = append(blocks, aiProviderSettingsBlocks[i])
+		}
+	}
+	return blocks
+}
+
+// The version accessors return null for an omitted block, so version changes
+// can be compared with writeOnlyVersionChanged.
+
+func (a *AIProviderAzureOpenAISettingsModel) credentialsVersion() types.Int64 {
+	if a == nil {
+		return types.Int64Null()
+	}
+	return a.CredentialsWOVersion
+}
+
+func (v *AIProviderVertexSettingsModel) credentialsVersion() types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return v.CredentialsWOVersion
+}
+
+func (o *AIProviderOpenAICompatibleSettingsModel) headersVersion() types.Int64 {
+	if o == nil {
+		return types.Int64Null()
+	}
+	return o.HeadersWOVersion
+}
+
 func bedrockRegion(baseURL string, configured, planned types.String) string {
 	if !configured.IsNull() && !configured.IsUnknown() {
 		return configured.ValueString()
@@ -3,6 +3,9 @@ package provider
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
@@ -12,6 +15,7 @@ import (
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/terraform-provider-coderd/integration"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/stretchr/testify/require"
 )
@@ -140,6 +145,84 @@ resource "coderd_ai_provider" "bedrock" {
 	return out.String()
 }
 
+func TestAccAIProviderResourceVerify(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "ai_provider_verify_acc", integration.UseLicense)
+
+	// The check runs from the provider, so a local fake upstream works.
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" || r.Header.Get("Authorization") != "Bearer sk-test-valid" {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	t.Cleanup(upstream.Close)
+
+	cfg := func(apiKey string, version int, verify string) string {
+		return fmt.Sprintf(`
+provider "coderd" {
+  url   = %q
+  token = %q
+}
+
+resource "coderd_ai_provider" "test" {
+  type               = "openai-compat"
+  name               = "verify-acc"
+  base_url           = "%s/v1"
+  api_key_wo         = %q
+  api_key_wo_version = %d
+  %s
+}
+`, client.URL.String(), client.SessionToken(), upstream.URL, apiKey, version, verify)
+	}
+
+	var verifiedAt string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		TerraformVersionChecks:   testAIProviderTerraformVersionChecks(),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg("sk-test-valid", 1, "verify = {}"),
+				Check: resource.TestCheckResourceAttrWith("coderd_ai_provider.test", "last_verified_at", func(value string) error {
+					verifiedAt = value
+					return nil
+				}),
+			},
+			{
+				Config:      cfg("sk-test-invalid", 2, "verify = { fail_on_error = true }"),
+				ExpectError: regexp.MustCompile("AI Provider Verification Failed"),
+			},
+			{
+				// The failed check kept the key change and the time of the
+				// last check that passed, so there's nothing left to apply.
+				Config: cfg("sk-test-invalid", 2, "verify = { fail_on_error = true }"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttrWith("coderd_ai_provider.test", "last_verified_at", func(value string) error {
+					if value != verifiedAt {
+						return fmt.Errorf("expected last_verified_at %s, got %s", verifiedAt, value)
+					}
+					return nil
+				}),
+			},
+			{
+				Config: cfg("sk-test-invalid", 2, ""),
+				Check:  resource.TestCheckNoResourceAttr("coderd_ai_provider.test", "last_verified_at"),
+			},
+		},
+	})
+}
+
 func TestAIProviderResourceSchemaValidation(t *testing.T) {
 	t.Parallel()
 
@@ -337,6 +420,106 @@ func TestAIProviderResourceSchemaValidation(t *testing.T) {
 `,
 			wantError: `Missing Bedrock Region`,
 		},
+		"azure_openai requires azure type": {
+			body: `resource "coderd_ai_provider" "test" {
+  type     = "openai"
+  name     = "openai-test"
+  base_url = "https://api.openai.com/v1"
+
+  settings = {
+    azure_openai = {
+      deployment = "gpt-4o"
+    }
+  }
+}
+`,
+			wantError: `only valid when .type. is .azure.`,
+		},
+		"only one settings block": {
+			body: `resource "coderd_ai_provider" "test" {
+  type     = "anthropic"
+  name     = "anthropic-test"
+  base_url = "https://us-east5-aiplatform.googleapis.com"
+
+  settings = {
+    bedrock = {
+      region = "us-east-1"
+    }
+    vertex = {
+      project  = "my-project"
+      location = "us-east5"
+    }
+  }
+}
+`,
+			wantError: `must include only one block`,
+		},
+		"vertex credentials must be json": {
+			body: `resource "coderd_ai_provider" "test" {
+  type     = "google"
+  name     = "vertex-test"
+  base_url = "https://us-central1-aiplatform.googleapis.com"
+
+  settings = {
+    vertex = {
+      project                = "my-project"
+      location               = "us-central1"
+      credentials_json_wo    = "not-json"
+      credentials_wo_version = 1
+    }
+  }
+}
+`,
+			wantError: `Invalid Vertex Credentials`,
+		},
+		"api key rejected with vertex": {
+			body: `resource "coderd_ai_provider" "test" {
+  type               = "google"
+  name               = "vertex-test"
+  base_url           = "https://us-central1-aiplatform.googleapis.com"
+  api_key_wo         = "key"
+  api_key_wo_version = 1
+
+  settings = {
+    vertex = {
+      project  = "my-project"
+      location = "us-central1"
+    }
+  }
+}
+`,
+			wantError: `must not be configured when .settings.vertex. is set`,
+		},
+		"azure client secret requires version": {
+			body: `resource "coderd_ai_provider" "test" {
+  type     = "azure"
+  name     = "azure-test"
+  base_url = "https://example.openai.azure.com/openai"
+
+  settings = {
+    azure_openai = {
+      tenant_id        = "tenant"
+      client_id        = "client"
+      client_secret_wo = "secret"
+    }
+  }
+}
+`,
+			wantError: `credentials_wo_version`,
+		},
+		"openai_compatible requires openai-compat type": {
+			body: `resource "coderd_ai_provider" "test" {
+  type     = "openai"
+  name     = "openai-test"
+  base_url = "https://api.openai.com/v1"
+
+  settings = {
+    openai_compatible = {}
+  }
+}
+`,
+			wantError: `only valid when .type. is .openai-compat.`,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
@@ -779,6 +962,111 @@ func TestAIProviderUpdateRotatesBedrockCredentials(t *testing.T) {
 	require.Empty(t, patch.Settings.Bedrock.ExternalID, "external_id is server-generated and must not be sent")
 }
 
+func TestAIProviderUpdateRotatesSettingsCredentials(t *testing.T) {
+	t.Parallel()
+
+	azureState := AIProviderResourceModel{
+		Type:    types.StringValue(string(codersdk.AIProviderTypeAzure)),
+		Enabled: types.BoolValue(true),
+		BaseURL: types.StringValue("https://example.openai.azure.com/openai"),
+		Settings: &AIProviderSettingsModel{AzureOpenAI: &AIProviderAzureOpenAISettingsModel{
+			Deployment:           types.StringValue("gpt-4o"),
+			CredentialsWOVersion: types.Int64Value(1),
+		}},
+	}
+
+	t.Run("version unchanged", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		patch := azureState.updateRequest(azureState, azureState, &diags)
+		require.False(t, diags.HasError(), diags.Errors())
+		require.NotNil(t, patch.Settings)
+		require.NotNil(t, patch.Settings.AzureOpenAI)
+		require.Equal(t, "gpt-4o", patch.Settings.AzureOpenAI.Deployment)
+		require.Nil(t, patch.Settings.AzureOpenAI.ClientSecret, "an unchanged version must preserve the stored secret")
+	})
+
+	t.Run("azure version bumped", func(t *testing.T) {
+		t.Parallel()
+		plan := azureState
+		plan.Settings = &AIProviderSettingsModel{AzureOpenAI: &AIProviderAzureOpenAISettingsModel{
+			Deployment:           types.StringValue("gpt-4o"),
+			CredentialsWOVersion: types.Int64Value(2),
+		}}
+		config := plan
+		config.Settings = &AIProviderSettingsModel{AzureOpenAI: &AIProviderAzureOpenAISettingsModel{
+			Deployment:           types.StringValue("gpt-4o"),
+			ClientSecretWO:       types.StringValue("rotated"),
+			CredentialsWOVersion: types.Int64Value(2),
+		}}
+		var diags diag.Diagnostics
+		patch := plan.updateRequest(azureState, config, &diags)
+		require.False(t, diags.HasError(), diags.Errors())
+		require.NotNil(t, patch.Settings.AzureOpenAI.ClientSecret)
+		require.Equal(t, "rotated", *patch.Settings.AzureOpenAI.ClientSecret)
+	})
+
+	t.Run("vertex version bumped without credentials", func(t *testing.T) {
+		t.Parallel()
+		state := AIProviderResourceModel{
+			Type:    types.StringValue(string(codersdk.AIProviderTypeGoogle)),
+			Enabled: types.BoolValue(true),
+			BaseURL: types.StringValue("https://us-central1-aiplatform.googleapis.com"),
+			Settings: &AIProviderSettingsModel{Vertex: &AIProviderVertexSettingsModel{
+				Project:              types.StringValue("my-project"),
+				Location:             types.StringValue("us-central1"),
+				CredentialsWOVersion: types.Int64Value(1),
+			}},
+		}
+		plan := state
+		plan.Settings = &AIProviderSettingsModel{Vertex: &AIProviderVertexSettingsModel{
+			Project:              types.StringValue("my-project"),
+			Location:             types.StringValue("us-central1"),
+			CredentialsWOVersion: types.Int64Value(2),
+		}}
+		var diags diag.Diagnostics
+		_ = plan.updateRequest(state, plan, &diags)
+		require.True(t, diags.HasError())
+		require.Contains(t, diags.Errors()[0].Summary(), "Missing Vertex Credentials")
+	})
+
+	t.Run("openai_compatible headers", func(t *testing.T) {
+		t.Parallel()
+		state := AIProviderResourceModel{
+			Type:     types.StringValue(string(codersdk.AIProviderTypeOpenAICompat)),
+			Enabled:  types.BoolValue(true),
+			BaseURL:  types.StringValue("https://gateway.example.com/v1"),
+			Settings: &AIProviderSettingsModel{OpenAICompatible: &AIProviderOpenAICompatibleSettingsModel{}},
+		}
+		plan := state
+		plan.Settings = &AIProviderSettingsModel{OpenAICompatible: &AIProviderOpenAICompatibleSettingsModel{
+			HeadersWOVersion: types.Int64Value(1),
+		}}
+		config := plan
+		config.Settings = &AIProviderSettingsModel{OpenAICompatible: &AIProviderOpenAICompatibleSettingsModel{
+			HeadersWO: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"X-Gateway-Key": types.StringValue("secret"),
+			}),
+			HeadersWOVersion: types.Int64Value(1),
+		}}
+		var diags diag.Diagnostics
+		patch := plan.updateRequest(state, config, &diags)
+		require.False(t, diags.HasError(), diags.Errors())
+		require.Equal(t, map[string]string{"X-Gateway-Key": "secret"}, patch.Settings.OpenAICompatible.Headers)
+	})
+
+	t.Run("block removed", func(t *testing.T) {
+		t.Parallel()
+		plan := azureState
+		plan.Settings = nil
+		var diags diag.Diagnostics
+		patch := plan.updateRequest(azureState, plan, &diags)
+		require.False(t, diags.HasError(), diags.Errors())
+		require.NotNil(t, patch.Settings, "removing a block must clear it server-side")
+		require.Nil(t, patch.Settings.AzureOpenAI)
+	})
+}
+
 func TestAIProviderUpdatePreservesBedrockCredentialsWhenVersionRemoved(t *testing.T) {
 	t.Parallel()
 
@@ -994,6 +1282,28 @@ func TestAIProviderCheckBedrockRoleARNDropped(t *testing.T) {
 	}
 }
 
+func TestAIProviderCheckSettingsBlocksDropped(t *testing.T) {
+	t.Parallel()
+
+	config := AIProviderResourceModel{
+		Settings: &AIProviderSettingsModel{Vertex: &AIProviderVertexSettingsModel{
+			Project:  types.StringValue("my-project"),
+			Location: types.StringValue("us-central1"),
+		}},
+	}
+
+	var diags diag.Diagnostics
+	checkSettingsBlocksDropped(config, codersdk.AIProvider{}, &diags)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags.Errors()[0].Summary(), "vertex not supported")
+
+	diags = nil
+	checkSettingsBlocksDropped(config, codersdk.AIProvider{Settings: codersdk.AIProviderSettings{
+		Vertex: &codersdk.AIProviderVertexSettings{Project: "my-project", Location: "us-central1"},
+	}}, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+}
+
 func TestAIProviderCheckBedrockProtocolDropped(t *testing.T) {
 	t.Parallel()
 
@@ -1120,3 +1430,47 @@ func TestAIProviderStateFromProviderRetainsProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestAIProviderStateFromProviderMapsSettingsBlocks(t *testing.T) {
+	t.Parallel()
+
+	prior := AIProviderResourceModel{Settings: &AIProviderSettingsModel{
+		AzureOpenAI: &AIProviderAzureOpenAISettingsModel{CredentialsWOVersion: types.Int64Value(3)},
+	}}
+	state := prior.stateFromProvider(codersdk.AIProvider{
+		ID:   uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Type: codersdk.AIProviderTypeAzure,
+		Name: "azure",
+		Settings: codersdk.AIProviderSettings{AzureOpenAI: &codersdk.AIProviderAzureOpenAISettings{
+			Deployment: "gpt-4o",
+		}},
+	})
+
+	require.NotNil(t, state.Settings)
+	require.NotNil(t, state.Settings.AzureOpenAI)
+	require.Nil(t, state.Settings.Bedrock)
+	require.Equal(t, types.StringValue("gpt-4o"), state.Settings.AzureOpenAI.Deployment)
+	require.Equal(t, types.StringNull(), state.Settings.AzureOpenAI.APIVersion)
+	require.Equal(t, types.StringNull(), state.Settings.AzureOpenAI.ClientSecretWO)
+	require.Equal(t, types.Int64Value(3), state.Settings.AzureOpenAI.CredentialsWOVersion)
+}
+
+func TestAIProviderStateFromProviderRetainsVerification(t *testing.T) {
+	t.Parallel()
+
+	prior := AIProviderResourceModel{
+		Verify: types.ObjectValueMust(verifyAttrTypes, map[string]attr.Value{
+			"timeout":       types.StringValue("30s"),
+			"fail_on_error": types.BoolValue(true),
+		}),
+		LastVerifiedAt: types.Int64Value(300),
+	}
+	state := prior.stateFromProvider(codersdk.AIProvider{
+		ID:   uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Type: codersdk.AIProviderTypeOpenAI,
+		Name: "openai",
+	})
+
+	require.Equal(t, prior.Verify, state.Verify)
+	require.Equal(t, prior.LastVerifiedAt, state.LastVerifiedAt)
+}
@@ -0,0 +1,492 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &AppearanceResource{}
+var _ resource.ResourceWithImportState = &AppearanceResource{}
+var _ resource.ResourceWithModifyPlan = &AppearanceResource{}
+var _ resourceWithEntitlements = &AppearanceResource{}
+
+// Matches the default banner color used by the Coder dashboard.
+const defaultAnnouncementBannerColor = "#004852"
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+var (
+	pathApplicationName     = path.Root("application_name")
+	pathLogoURL             = path.Root("logo_url")
+	pathAnnouncementBanners = path.Root("announcement_banners")
+)
+
+type AppearanceResource struct {
+	*CoderdProviderData
+}
+
+type AppearanceResourceModel struct {
+	ApplicationName     types.String `tfsdk:"application_name"`
+	LogoURL             types.String `tfsdk:"logo_url"`
+	AnnouncementBanners types.List   `tfsdk:"announcement_banners"`
+	DocsURL             types.String `tfsdk:"docs_url"`
+	SupportLinks        types.List   `tfsdk:"support_links"`
+	AllowOverwrite      types.Bool   `tfsdk:"allow_overwrite"`
+}
+
+type AnnouncementBanner struct {
+	Message         types.String `tfsdk:"message"`
+	BackgroundColor types.String `tfsdk:"background_color"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+}
+
+type SupportLink struct {
+	Name     types.String `tfsdk:"name"`
+	Target   types.String `tfsdk:"target"`
+	Icon     types.String `tfsdk:"icon"`
+	Location types.String `tfsdk:"location"`
+}
+
+var announcementBannerType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"message":          types.StringType,
+		"background_color": types.StringType,
+		"enabled":          types.BoolType,
+	},
+}
+
+var supportLinkType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":     types.StringType,
+		"target":   types.StringType,
+		"icon":     types.StringType,
+		"location": types.StringType,
+	},
+}
+
+func NewAppearanceResource() resource.Resource {
+	return &AppearanceResource{}
+}
+
+func (r *AppearanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_appearance"
+}
+
+func (r *AppearanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The deployment-wide appearance settings: the application name, logo, and announcement banners shown in the dashboard.
+
+Declare this resource at most once per deployment. Planning fails if the appearance changed outside this configuration since it was last applied, unless ` + "`allow_overwrite`" + ` is set.
+
+~> **Warning**
+If the appearance was configured out of band, ` + "`terraform import`" + ` this resource before the first apply. Otherwise Terraform overwrites the live values; a plan-time warning is emitted when this is about to happen.
+
+~> **Warning**
+` + "`terraform destroy`" + ` resets the application name and logo to Coder's defaults and removes every announcement banner. The API has no delete operation for these settings.
+
+-> The docs URL and support links are configured with the ` + "`CODER_DOCS_URL`" + ` and ` + "`CODER_SUPPORT_LINKS`" + ` server options, so they are exposed as read-only attributes.
+
+~> **Warning**
+This resource requires an Enterprise or Premium license.
+`,
+		Attributes: map[string]schema.Attribute{
+			"allow_overwrite": allowOverwriteSchema("the appearance settings"),
+			"application_name": schema.StringAttribute{
+				MarkdownDescription: "The application name shown in the dashboard and browser title. Defaults to an empty string, which displays `Coder`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"logo_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the logo shown in the dashboard. Defaults to an empty string, which displays the Coder logo.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"announcement_banners": schema.ListNestedAttribute{
+				MarkdownDescription: "Banners shown at the top of every dashboard page, in order. Defaults to no banners.",
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(announcementBannerType, []attr.Value{})),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"message": schema.StringAttribute{
+							MarkdownDescription: "The banner text. Markdown is supported.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"background_color": schema.StringAttribute{
+							MarkdownDescription: "Background color of the banner as a hex color. Defaults to `" + defaultAnnouncementBannerColor + "`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(defaultAnnouncementBannerColor),
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(hexColorRegex, "must be a hex color such as `#004852`"),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the banner is shown. Defaults to true.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"docs_url": schema.StringAttribute{
+				MarkdownDescription: "The documentation URL linked from the dashboard, set by the `CODER_DOCS_URL` server option.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"support_links": schema.ListNestedAttribute{
+				MarkdownDescription: "Links shown in the dashboard's support menu, set by the `CODER_SUPPORT_LINKS` server option.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Label of the link.",
+							Computed:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "URL the link points to.",
+							Computed:            true,
+						},
+						"icon": schema.StringAttribute{
+							MarkdownDescription: "Icon shown next to the link, such as `bug`, `chat`, `docs` or `star`.",
+							Computed:            true,
+						},
+						"location": schema.StringAttribute{
+							MarkdownDescription: "Where the link is shown, either `navbar` or `dropdown`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AppearanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *AppearanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AppearanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appearance, err := r.Client.Appearance(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read appearance, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.readResponse(ctx, appearance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private, newAppearanceFingerprint(appearance))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppearanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AppearanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating appearance")
+
+	resp.Diagnostics.Append(r.put(ctx, "create", &data, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "successfully created appearance")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppearanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AppearanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating appearance")
+
+	resp.Diagnostics.Append(r.put(ctx, "update", &data, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "successfully updated appearance")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// put writes the configured appearance, then reads it back so the read-only
+// attributes and the singleton fingerprint reflect what was stored.
+func (r *AppearanceResource) put(ctx context.Context, action string, data *AppearanceResourceModel, ps privateState) (diags diag.Diagnostics) {
+	banners, diags := data.bannerConfigs(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	err := r.Client.UpdateAppearance(ctx, codersdk.UpdateAppearanceConfig{
+		ApplicationName:     data.ApplicationName.ValueString(),
+		LogoURL:             data.LogoURL.ValueString(),
+		AnnouncementBanners: banners,
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s appearance, got error: %s", action, err))
+		return diags
+	}
+
+	appearance, err := r.Client.Appearance(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read appearance, got error: %s", err))
+		return diags
+	}
+	data.DocsURL = types.StringValue(appearance.DocsURL)
+	supportLinks, linkDiags := supportLinksFromConfig(ctx, appearance.SupportLinks)
+	diags.Append(linkDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	data.SupportLinks = supportLinks
+
+	diags.Append(recordSingletonFingerprint(ctx, ps, newAppearanceFingerprint(appearance))...)
+	return diags
+}
+
+func (r *AppearanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleting appearance")
+
+	// The API has no DELETE, so restore the deployment defaults.
+	err := r.Client.UpdateAppearance(ctx, codersdk.UpdateAppearanceConfig{
+		AnnouncementBanners: []codersdk.BannerConfig{},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset appearance, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "successfully deleted appearance")
+}
+
+// Entitlements implements resourceWithEntitlements.
+func (r *AppearanceResource) Entitlements() []entitlement {
+	return []entitlement{{
+		Feature: codersdk.FeatureAppearance,
+		Usage:   "customize the deployment appearance",
+	}}
+}
+
+func (r *AppearanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if r.CoderdProviderData == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.claimSingleton("coderd_appearance")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Import populates state without running Create, so only warn on true
+	// creates. Existing resources are checked against the last-applied value.
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_appearance", func() (any, error) {
+			live, err := r.Client.Appearance(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return newAppearanceFingerprint(live), nil
+		})...)
+		return
+	}
+
+	var data AppearanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live, err := r.Client.Appearance(ctx)
+	if err != nil {
+		// This lookup is advisory; CRUD reports endpoint failures.
+		tflog.Debug(ctx, "skipping appearance plan-time check", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	const detail = "Terraform has no prior state for this resource, so this change is not shown as a diff.\n\n" +
+		"If you meant to adopt the deployment's existing value rather than overwrite it, run " +
+		"`terraform import coderd_appearance.<name> appearance` first."
+	if !data.ApplicationName.IsUnknown() && live.ApplicationName != "" && live.ApplicationName != data.ApplicationName.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(pathApplicationName, "Overwriting an out-of-band value",
+			fmt.Sprintf("The application name is currently %q on this deployment, and applying will overwrite it. %s", live.ApplicationName, detail))
+	}
+	if !data.LogoURL.IsUnknown() && live.LogoURL != "" && live.LogoURL != data.LogoURL.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(pathLogoURL, "Overwriting an out-of-band value",
+			fmt.Sprintf("The logo URL is currently %q on this deployment, and applying will overwrite it. %s", live.LogoURL, detail))
+	}
+	if data.AnnouncementBanners.IsUnknown() || len(live.AnnouncementBanners) == 0 {
+		return
+	}
+	banners, diags := data.bannerConfigs(ctx)
+	if diags.HasError() {
+		// Nested values can still be unknown during planning.
+		return
+	}
+	if !slices.Equal(live.AnnouncementBanners, banners) {
+		resp.Diagnostics.AddAttributeWarning(pathAnnouncementBanners, "Overwriting an out-of-band value",
+			fmt.Sprintf("This deployment already has %d announcement banner(s) configured, and applying will replace them. %s",
+				len(live.AnnouncementBanners), detail))
+	}
+}
+
+func (r *AppearanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The singleton has no ID, but Read needs a non-null placeholder state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, AppearanceResourceModel{
+		ApplicationName:     types.StringValue(""),
+		LogoURL:             types.StringValue(""),
+		AnnouncementBanners: types.ListValueMust(announcementBannerType, []attr.Value{}),
+		DocsURL:             types.StringValue(""),
+		SupportLinks:        types.ListValueMust(supportLinkType, []attr.Value{}),
+	})...)
+}
+
+func (m *AppearanceResourceModel) readResponse(ctx context.Context, appearance codersdk.AppearanceConfig) (diags diag.Diagnostics) {
+	banners := make([]AnnouncementBanner, 0, len(appearance.AnnouncementBanners))
+	for _, banner := range appearance.AnnouncementBanners {
+		banners = append(banners, AnnouncementBanner{
+			Message:         types.StringValue(banner.Message),
+			BackgroundColor: types.StringValue(banner.BackgroundColor),
+			Enabled:         types.BoolValue(banner.Enabled),
+		})
+	}
+	bannerList, d := types.ListValueFrom(ctx, announcementBannerType, banners)
+	diags.Append(d...)
+	supportLinks, d := supportLinksFromConfig(ctx, appearance.SupportLinks)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.ApplicationName = types.StringValue(appearance.ApplicationName)
+	m.LogoURL = types.StringValue(appearance.LogoURL)
+	m.AnnouncementBanners = bannerList
+	m.DocsURL = types.StringValue(appearance.DocsURL)
+	m.SupportLinks = supportLinks
+	return diags
+}
+
+func (m *AppearanceResourceModel) bannerConfigs(ctx context.Context) ([]codersdk.BannerConfig, diag.Diagnostics) {
+	var banners []AnnouncementBanner
+	diags := m.AnnouncementBanners.ElementsAs(ctx, &banners, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	configs := make([]codersdk.BannerConfig, 0, len(banners))
+	for _, banner := range banners {
+		configs = append(configs, codersdk.BannerConfig{
+			Enabled:         banner.Enabled.ValueBool(),
+			Message:         banner.Message.ValueString(),
+			BackgroundColor: banner.BackgroundColor.ValueString(),
+		})
+	}
+	return configs, diags
+}
+
+func supportLinksFromConfig(ctx context.Context, links []codersdk.LinkConfig) (types.List, diag.Diagnostics) {
+	out := make([]SupportLink, 0, len(links))
+	for _, link := range links {
+		out = append(out, SupportLink{
+			Name:     types.StringValue(link.Name),
+			Target:   types.StringValue(link.Target),
+			Icon:     types.StringValue(link.Icon),
+			Location: types.StringValue(link.Location),
+		})
+	}
+	return types.ListValueFrom(ctx, supportLinkType, out)
+}
+
+// appearanceFingerprint is the value fingerprinted for singleton conflict
+// detection. Only the settings this resource writes are included.
+type appearanceFingerprint struct {
+	ApplicationName     string                  `json:"application_name"`
+	LogoURL             string                  `json:"logo_url"`
+	AnnouncementBanners []codersdk.BannerConfig `json:"announcement_banners"`
+}
+
+func newAppearanceFingerprint(appearance codersdk.AppearanceConfig) appearanceFingerprint {
+	banners := appearance.AnnouncementBanners
+	if banners == nil {
+		banners = []codersdk.BannerConfig{}
+	}
+	return appearanceFingerprint{
+		ApplicationName:     appearance.ApplicationName,
+		LogoURL:             appearance.LogoURL,
+		AnnouncementBanners: banners,
+	}
+}
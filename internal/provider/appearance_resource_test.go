@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccAppearanceResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "appearance_acc", integration.UseLicense)
+
+	cfg1 := testAccAppearanceResourceConfig{
+		URL:             client.URL.String(),
+		Token:           client.SessionToken(),
+		ApplicationName: ptr.Ref("Acme Dev"),
+		LogoURL:         ptr.Ref("https://example.com/logo.png"),
+		Banners: []testAccAnnouncementBannerConfig{
+			{Message: "Maintenance on Saturday"},
+		},
+	}
+
+	cfg2 := cfg1
+	cfg2.ApplicationName = ptr.Ref("Acme Cloud")
+	cfg2.Banners = []testAccAnnouncementBannerConfig{
+		{Message: "Maintenance on Saturday", BackgroundColor: ptr.Ref("#ff0000"), Enabled: ptr.Ref(false)},
+		{Message: "New templates available"},
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg1.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_appearance.test", "application_name", "Acme Dev"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "logo_url", "https://example.com/logo.png"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.#", "1"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.0.background_color", defaultAnnouncementBannerColor),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.0.enabled", "true"),
+					resource.TestCheckResourceAttrSet("coderd_appearance.test", "docs_url"),
+				),
+			},
+			{
+				Config: cfg1.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: cfg2.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_appearance.test", "application_name", "Acme Cloud"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.#", "2"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.0.background_color", "#ff0000"),
+					resource.TestCheckResourceAttr("coderd_appearance.test", "announcement_banners.0.enabled", "false"),
+				),
+			},
+			{
+				Config:            cfg2.String(t),
+				ResourceName:      "coderd_appearance.test",
+				ImportState:       true,
+				ImportStateId:     "appearance",
+				ImportStateVerify: true,
+				// Import has no resource ID to match on.
+				ImportStateVerifyIdentifierAttribute: "application_name",
+			},
+		},
+	})
+
+	// Destroy restores the deployment defaults.
+	appearance, err := client.Appearance(ctx)
+	require.NoError(t, err)
+	require.Empty(t, appearance.ApplicationName)
+	require.Empty(t, appearance.LogoURL)
+	require.Empty(t, appearance.AnnouncementBanners)
+}
+
+type testAccAppearanceResourceConfig struct {
+	URL   string
+	Token string
+
+	ApplicationName *string
+	LogoURL         *string
+	Banners         []testAccAnnouncementBannerConfig
+}
+
+type testAccAnnouncementBannerConfig struct {
+	Message         string
+	BackgroundColor *string
+	Enabled         *bool
+}
+
+func (c testAccAppearanceResourceConfig) String(t *testing.T) string {
+	t.Helper()
+	tpl := `
+provider coderd {
+	url   = "{{.URL}}"
+	token = "{{.Token}}"
+}
+
+resource "coderd_appearance" "test" {
+	application_name = {{orNull .ApplicationName}}
+	logo_url         = {{orNull .LogoURL}}
+
+	announcement_banners = [
+		{{- range .Banners}}
+		{
+			message          = "{{.Message}}"
+			background_color = {{orNull .BackgroundColor}}
+			enabled          = {{orNull .Enabled}}
+		},
+		{{- end}}
+	]
+}
+`
+	funcMap := template.FuncMap{
+		"orNull": PrintOrNull,
+	}
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("appearanceResource").Funcs(funcMap).Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, c)
+	require.NoError(t, err)
+	return buf.String()
+}
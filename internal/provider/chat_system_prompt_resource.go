@@ -42,6 +42,7 @@ type ChatSystemPromptResource struct {
 type ChatSystemPromptResourceModel struct {
 	SystemPrompt               chatSystemPromptTextValue `tfsdk:"system_prompt"`
 	IncludeDefaultSystemPrompt types.Bool                `tfsdk:"include_default_system_prompt"`
+	AllowOverwrite             types.Bool                `tfsdk:"allow_overwrite"`
 }
 
 func NewChatSystemPromptResource() resource.Resource {
@@ -58,7 +59,7 @@ func (r *ChatSystemPromptResource) Schema(ctx context.Context, req resource.Sche
 
 The deployment-wide chat system prompt for Coder Agents (` + "`Settings → Instructions`" + ` in the dashboard).
 
-This is a deployment-wide singleton. Declare it once; duplicate resources silently overwrite each other.
+There is one prompt per deployment, so declare this resource at most once. If the prompt is edited elsewhere after this configuration applies it, the next plan fails unless ` + "`allow_overwrite`" + ` is set.
 
 Coder sanitizes the stored prompt (strips invisible Unicode characters, normalizes line endings, collapses runs of blank lines, and trims surrounding whitespace), and this resource compares values the same way, so a trailing newline from ` + "`file(...)`" + ` does not cause drift after apply. On the first plan after an import, a configured value that differs from the live one only by sanitization shows a single in-place normalization update and then converges; use ` + "`trimspace(file(...))`" + ` to avoid even that.
 
@@ -72,6 +73,7 @@ If a system prompt was configured out of band, ` + "`terraform import`" + ` this
 This resource requires Coder version [` + chatSystemPromptMinVersion + `](https://github.com/coder/coder/releases/tag/v` + chatSystemPromptMinVersion + `) or later, and a token with site-wide ` + "`owner`" + ` permissions.
 `,
 		Attributes: map[string]schema.Attribute{
+			"allow_overwrite": allowOverwriteSchema("the prompt"),
 			"system_prompt": schema.StringAttribute{
 				CustomType: chatSystemPromptTextType{},
 				Required:   true,
@@ -129,6 +131,8 @@ func (r *ChatSystemPromptResource) Read(ctx context.Context, req resource.ReadRe
 	data.SystemPrompt = newChatSystemPromptTextValue(prompt.SystemPrompt)
 	data.IncludeDefaultSystemPrompt = types.BoolValue(prompt.IncludeDefaultSystemPrompt)
 
+	resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private,
+		newChatSystemPromptFingerprint(prompt.SystemPrompt, prompt.IncludeDefaultSystemPrompt))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -148,6 +152,8 @@ func (r *ChatSystemPromptResource) Create(ctx context.Context, req resource.Crea
 
 	tflog.Trace(ctx, "successfully created chat system prompt")
 
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private,
+		newChatSystemPromptFingerprint(data.SystemPrompt.ValueString(), data.IncludeDefaultSystemPrompt.ValueBool()))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -167,6 +173,8 @@ func (r *ChatSystemPromptResource) Update(ctx context.Context, req resource.Upda
 
 	tflog.Trace(ctx, "successfully updated chat system prompt")
 
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private,
+		newChatSystemPromptFingerprint(data.SystemPrompt.ValueString(), data.IncludeDefaultSystemPrompt.ValueBool()))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -213,11 +221,23 @@ func (r *ChatSystemPromptResource) ModifyPlan(ctx context.Context, req resource.
 	if req.Plan.Raw.IsNull() {
 		return
 	}
-	// Import populates state without running Create, so only warn on true creates.
-	if !req.State.Raw.IsNull() {
+	if r.CoderdProviderData == nil {
 		return
 	}
-	if r.CoderdProviderData == nil {
+	resp.Diagnostics.Append(r.claimSingleton("coderd_chat_system_prompt")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Import populates state without running Create, so only warn on true
+	// creates. Existing resources are checked against the last-applied value.
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_chat_system_prompt", func() (any, error) {
+			live, err := r.experimentalClient().GetChatSystemPrompt(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return newChatSystemPromptFingerprint(live.SystemPrompt, live.IncludeDefaultSystemPrompt), nil
+		})...)
 		return
 	}
 
@@ -274,6 +294,21 @@ func (r *ChatSystemPromptResource) ImportState(ctx context.Context, req resource
 	})...)
 }
 
+// chatSystemPromptFingerprint is the value fingerprinted for singleton
+// conflict detection. The prompt is sanitized so server-side normalization is
+// not mistaken for a foreign write.
+type chatSystemPromptFingerprint struct {
+	SystemPrompt               string `json:"system_prompt"`
+	IncludeDefaultSystemPrompt bool   `json:"include_default_system_prompt"`
+}
+
+func newChatSystemPromptFingerprint(prompt string, includeDefault bool) chatSystemPromptFingerprint {
+	return chatSystemPromptFingerprint{
+		SystemPrompt:               codersdk.SanitizePromptText(prompt),
+		IncludeDefaultSystemPrompt: includeDefault,
+	}
+}
+
 func chatSystemPromptDiag(action string, err error) diag.Diagnostics {
 	var diags diag.Diagnostics
 
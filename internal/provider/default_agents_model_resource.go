@@ -40,8 +40,9 @@ func (r *DefaultAgentsModelResource) experimentalClient() *codersdk.Experimental
 }
 
 type DefaultAgentsModelResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	ModelID UUID         `tfsdk:"model_id"`
+	ID             types.String `tfsdk:"id"`
+	ModelID        UUID         `tfsdk:"model_id"`
+	AllowOverwrite types.Bool   `tfsdk:"allow_overwrite"`
 }
 
 func (r *DefaultAgentsModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +54,56 @@ func (r *DefaultAgentsModelResource) ModifyPlan(ctx context.Context, req resourc
 		"Experimental Resource",
 		"coderd_default_agents_model is experimental. Changes are expected, and it is not recommended for production use.",
 	)
+
+	if req.Plan.Raw.IsNull() || r.data == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.data.claimSingleton("coderd_default_agents_model")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_default_agents_model", func() (any, error) {
+			return r.liveDefault(ctx)
+		})...)
+		return
+	}
+
+	var plan DefaultAgentsModelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ModelID.IsUnknown() {
+		return
+	}
+	live, err := r.liveDefault(ctx)
+	if err != nil {
+		// This lookup is advisory; CRUD reports endpoint failures.
+		tflog.Debug(ctx, "skipping default Agents model plan-time check", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	if live == uuid.Nil || live == plan.ModelID.ValueUUID() {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("model_id"), "Overwriting an out-of-band value",
+		fmt.Sprintf("The default Agents model is currently %s on this deployment, and applying will change it. Terraform "+
+			"has no prior state for this resource, so this change is not shown as a diff. If another configuration "+
+			"manages the default model, remove this resource instead.", live))
+}
+
+// liveDefault returns the ID of the deployment's current default model, or
+// uuid.Nil if there are no models.
+func (r *DefaultAgentsModelResource) liveDefault(ctx context.Context) (uuid.UUID, error) {
+	configs, err := r.experimentalClient().ListChatModelConfigs(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, config := range configs {
+		if config.IsDefault {
+			return config.ID, nil
+		}
+	}
+	return uuid.Nil, nil
 }
 
 func (r *DefaultAgentsModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -61,7 +112,9 @@ func (r *DefaultAgentsModelResource) Schema(ctx context.Context, req resource.Sc
 			"Selects which `coderd_agents_model` is the deployment-wide default chat model for Coder Agents.\n\n" +
 			"Coder enforces a single default model globally: marking a model as default automatically demotes the " +
 			"previous default in the same operation. Because the default is a global singleton, only one " +
-			"`coderd_default_agents_model` resource should exist per deployment.\n\n" +
+			"`coderd_default_agents_model` resource should exist per deployment. Planning a second instance in the same " +
+			"configuration fails with an error. If the default changes outside this configuration, the next plan fails " +
+			"unless `allow_overwrite` is set.\n\n" +
 			"Destroying this resource does not clear the default server-side. Coder always keeps exactly one model " +
 			"marked as default and force-promotes a replacement when the current default is removed, so deleting this " +
 			"resource only stops Terraform from managing which model is default.",
@@ -76,6 +129,7 @@ func (r *DefaultAgentsModelResource) Schema(ctx context.Context, req resource.Sc
 				CustomType:          UUIDType,
 				Required:            true,
 			},
+			"allow_overwrite": allowOverwriteSchema("the default model"),
 		},
 	}
 }
@@ -108,6 +162,8 @@ func (r *DefaultAgentsModelResource) Create(ctx context.Context, req resource.Cr
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set default Agents model, got error: %s", err))
 		return
 	}
+	state.AllowOverwrite = plan.AllowOverwrite
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, state.ModelID.ValueUUID())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -126,7 +182,10 @@ func (r *DefaultAgentsModelResource) Read(ctx context.Context, req resource.Read
 
 	for _, config := range configs {
 		if config.IsDefault {
+			allowOverwrite := state.AllowOverwrite
 			state = stateFromDefaultModelConfig(config)
+			state.AllowOverwrite = allowOverwrite
+			resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private, config.ID)...)
 			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 			return
 		}
@@ -152,6 +211,8 @@ func (r *DefaultAgentsModelResource) Update(ctx context.Context, req resource.Up
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update default Agents model, got error: %s", err))
 		return
 	}
+	state.AllowOverwrite = plan.AllowOverwrite
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, state.ModelID.ValueUUID())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/coder/coder/v2/coderd/util/ptr"
@@ -174,6 +175,18 @@ provider "coderd" {
 resource "coderd_default_agents_model" "default" {
   model_id = %q
 }
+`, client.URL.String(), client.SessionToken(), sonnet.ID.String())
+
+	allowOverwriteCfg := fmt.Sprintf(`
+provider "coderd" {
+  url   = %q
+  token = %q
+}
+
+resource "coderd_default_agents_model" "default" {
+  model_id        = %q
+  allow_overwrite = true
+}
 `, client.URL.String(), client.SessionToken(), sonnet.ID.String())
 
 	resource.Test(t, resource.TestCase{
@@ -202,20 +215,21 @@ resource "coderd_default_agents_model" "default" {
 			},
 			{
 				// Externally re-point the default to opus, then expect Terraform to
-				// detect the drift on refresh and plan to restore sonnet.
+				// detect the change and refuse to plan over it.
 				PreConfig: func() {
 					_, err := exp.UpdateChatModelConfig(ctx, opus.ID, codersdk.UpdateChatModelConfigRequest{
 						IsDefault: ptr.Ref(true),
 					})
 					require.NoError(t, err, "externally set opus as default")
 				},
-				Config:             cfg,
-				PlanOnly:           true,
-				ExpectNonEmptyPlan: true,
+				Config:      cfg,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Singleton Changed Elsewhere`),
 			},
 			{
-				// Re-applying reconciles the default back to sonnet.
-				Config: cfg,
+				// With allow_overwrite, applying reconciles the default back
+				// to sonnet.
+				Config: allowOverwriteCfg,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("coderd_default_agents_model.default", "model_id", sonnet.ID.String()),
 					checkServerDefaultMatchesResource(ctx, t, client),
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// staticSiteRoles are the built-in site roles a user can be assigned. Plan-time
+// validation falls back to these when the deployment's roles can't be listed.
+var staticSiteRoles = []string{"owner", "template-admin", "user-admin", "auditor"}
+
+// cachedLookup memoizes a deployment lookup for the lifetime of a provider
+// instance, so that planning many resources makes a single request. Failures
+// are cached too, so an unreachable endpoint is only tried once.
+type cachedLookup[T any] struct {
+	mu   sync.Mutex
+	done bool
+	val  T
+	err  error
+}
+
+func (c *cachedLookup[T]) get(fetch func() (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done {
+		c.val, c.err = fetch()
+		c.done = true
+	}
+	return c.val, c.err
+}
+
+// assignableSiteRoles returns the names of the site roles the provider's
+// token can assign, custom roles included.
+func (d *CoderdProviderData) assignableSiteRoles(ctx context.Context) ([]string, error) {
+	return d.siteRoles.get(func() ([]string, error) {
+		roles, err := d.Client.ListSiteRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(roles))
+		for _, role := range roles {
+			if role.Assignable {
+				names = append(names, role.Name)
+			}
+		}
+		return names, nil
+	})
+}
+
+// enabledLoginTypes returns the login types the deployment accepts. `none` is
+// always accepted.
+func (d *CoderdProviderData) enabledLoginTypes(ctx context.Context) ([]string, error) {
+	return d.loginTypes.get(func() ([]string, error) {
+		methods, err := d.Client.AuthMethods(ctx)
+		if err != nil {
+			return nil, err
+		}
+		enabled := []string{"none"}
+		if methods.Password.Enabled {
+			enabled = append(enabled, string(codersdk.LoginTypePassword))
+		}
+		if methods.Github.Enabled {
+			enabled = append(enabled, string(codersdk.LoginTypeGithub))
+		}
+		if methods.OIDC.Enabled {
+			enabled = append(enabled, string(codersdk.LoginTypeOIDC))
+		}
+		return enabled, nil
+	})
+}
+
+// validateSiteRoles errors for each role the deployment doesn't let the
+// provider's token assign. If the roles can't be listed, it falls back to
+// staticSiteRoles.
+func (d *CoderdProviderData) validateSiteRoles(ctx context.Context, p path.Path, roles []string) (diags diag.Diagnostics) {
+	if len(roles) == 0 {
+		return diags
+	}
+	valid, err := d.assignableSiteRoles(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to list site roles, validating against built-in roles", map[string]any{
+			"error": err.Error(),
+		})
+		valid = staticSiteRoles
+	}
+	for _, role := range roles {
+		if slices.Contains(valid, role) {
+			continue
+		}
+		diags.AddAttributeError(p,
+			"Invalid Role",
+			fmt.Sprintf("%q is not an assignable site role on this deployment. Valid roles are: %s.", role, strings.Join(valid, ", ")),
+		)
+	}
+	return diags
+}
+
+// validateLoginType warns if the deployment has the login type's auth method
+// disabled. It is not an error, since coderd accepts such users and they can
+// log in once the method is enabled. If the auth methods can't be fetched, the
+// check is skipped and the schema's static list applies.
+func (d *CoderdProviderData) validateLoginType(ctx context.Context, p path.Path, loginType string) (diags diag.Diagnostics) {
+	enabled, err := d.enabledLoginTypes(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to get auth methods, skipping login type check", map[string]any{
+			"error": err.Error(),
+		})
+		return diags
+	}
+	if slices.Contains(enabled, loginType) {
+		return diags
+	}
+	diags.AddAttributeWarning(p,
+		"Login Type Not Enabled",
+		fmt.Sprintf("The %q auth method is not enabled on this deployment, so the user won't be able to log in until it is. "+
+			"Enabled login types are: %s.", loginType, strings.Join(enabled, ", ")),
+	)
+	return diags
+}
+
+// validateGroupSyncMapping errors for each group ID in a group sync mapping
+// that is not a group of the organization. Unknown IDs, such as those of
+// groups created in the same apply, are skipped. If the groups can't be
+// listed, the check is skipped.
+func (d *CoderdProviderData) validateGroupSyncMapping(ctx context.Context, p path.Path, orgID uuid.UUID, mapping types.Map) (diags diag.Diagnostics) {
+	if mapping.IsNull() || mapping.IsUnknown() {
+		return diags
+	}
+	groups, err := d.Client.GroupsByOrganization(ctx, orgID)
+	if err != nil {
+		tflog.Debug(ctx, "unable to list organization groups, skipping group sync mapping check", map[string]any{
+			"error": err.Error(),
+		})
+		return diags
+	}
+	valid := make(map[uuid.UUID]struct{}, len(groups))
+	for _, group := range groups {
+		valid[group.ID] = struct{}{}
+	}
+	for claim, elem := range mapping.Elements() {
+		list, ok := elem.(types.List)
+		if !ok || list.IsUnknown() {
+			continue
+		}
+		for _, v := range list.Elements() {
+			id, ok := v.(UUID)
+			if !ok || id.IsUnknown() || id.IsNull() {
+				continue
+			}
+			if _, ok := valid[id.ValueUUID()]; ok {
+				continue
+			}
+			diags.AddAttributeError(p.AtMapKey(claim),
+				"Invalid Group Sync Mapping",
+				fmt.Sprintf("Group %s does not exist in organization %s.", id.ValueString(), orgID),
+			)
+		}
+	}
+	return diags
+}
+
+// validateRoleSyncMapping errors for each role name in a role sync mapping
+// that is not a role of the organization. If the roles can't be listed, the
+// check is skipped.
+func (d *CoderdProviderData) validateRoleSyncMapping(ctx context.Context, p path.Path, orgID uuid.UUID, mapping types.Map) (diags diag.Diagnostics) {
+	if mapping.IsNull() || mapping.IsUnknown() {
+		return diags
+	}
+	roles, err := d.Client.ListOrganizationRoles(ctx, orgID)
+	if err != nil {
+		tflog.Debug(ctx, "unable to list organization roles, skipping role sync mapping check", map[string]any{
+			"error": err.Error(),
+		})
+		return diags
+	}
+	valid := make([]string, 0, len(roles))
+	for _, role := range roles {
+		valid = append(valid, role.Name)
+	}
+	for claim, elem := range mapping.Elements() {
+		list, ok := elem.(types.List)
+		if !ok || list.IsUnknown() {
+			continue
+		}
+		for _, v := range list.Elements() {
+			role, ok := v.(types.String)
+			if !ok || role.IsUnknown() || role.IsNull() || slices.Contains(valid, role.ValueString()) {
+				continue
+			}
+			diags.AddAttributeError(p.AtMapKey(claim),
+				"Invalid Role Sync Mapping",
+				fmt.Sprintf("%q is not a role of organization %s. Valid roles are: %s.", role.ValueString(), orgID, strings.Join(valid, ", ")),
+			)
+		}
+	}
+	return diags
+}
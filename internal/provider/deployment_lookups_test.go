@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// newLookupTestData returns provider data backed by a server that answers
+// every request with handler, and a counter of the requests it served.
+func newLookupTestData(t *testing.T, handler func(w http.ResponseWriter)) (*CoderdProviderData, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		handler(w)
+	}))
+	t.Cleanup(srv.Close)
+
+	clientURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return &CoderdProviderData{Client: codersdk.New(clientURL)}, &requests
+}
+
+func respondJSON(v any) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func respondError(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(codersdk.Response{Message: "boom"})
+}
+
+func TestValidateSiteRoles(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	rolesPath := path.Root("roles")
+
+	t.Run("DeploymentRoles", func(t *testing.T) {
+		t.Parallel()
+		data, requests := newLookupTestData(t, respondJSON([]codersdk.AssignableRoles{
+			{Role: codersdk.Role{Name: "owner"}, Assignable: true},
+			{Role: codersdk.Role{Name: "workspace-creator"}, Assignable: true},
+			{Role: codersdk.Role{Name: "auditor"}, Assignable: false},
+		}))
+
+		require.False(t, data.validateSiteRoles(ctx, rolesPath, []string{"owner", "workspace-creator"}).HasError())
+		diags := data.validateSiteRoles(ctx, rolesPath, []string{"auditor", "template-admin"})
+		require.Len(t, diags.Errors(), 2)
+		require.Equal(t, "Invalid Role", diags.Errors()[0].Summary())
+		// The roles are listed once per provider instance.
+		require.EqualValues(t, 1, requests.Load())
+	})
+
+	t.Run("FallbackToBuiltInRoles", func(t *testing.T) {
+		t.Parallel()
+		data, requests := newLookupTestData(t, respondError)
+
+		require.False(t, data.validateSiteRoles(ctx, rolesPath, []string{"template-admin"}).HasError())
+		require.True(t, data.validateSiteRoles(ctx, rolesPath, []string{"workspace-creator"}).HasError())
+		require.EqualValues(t, 1, requests.Load())
+	})
+
+	t.Run("NothingToValidate", func(t *testing.T) {
+		t.Parallel()
+		data, requests := newLookupTestData(t, respondError)
+
+		require.False(t, data.validateSiteRoles(ctx, rolesPath, nil).HasError())
+		require.Zero(t, requests.Load())
+	})
+}
+
+func TestValidateLoginType(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	loginTypePath := path.Root("login_type")
+
+	t.Run("EnabledMethods", func(t *testing.T) {
+		t.Parallel()
+		data, _ := newLookupTestData(t, respondJSON(codersdk.AuthMethods{
+			Password: codersdk.AuthMethod{Enabled: true},
+		}))
+
+		require.Empty(t, data.validateLoginType(ctx, loginTypePath, "none"))
+		require.Empty(t, data.validateLoginType(ctx, loginTypePath, "password"))
+		diags := data.validateLoginType(ctx, loginTypePath, "oidc")
+		require.False(t, diags.HasError())
+		require.Len(t, diags.Warnings(), 1)
+		require.Equal(t, "Login Type Not Enabled", diags.Warnings()[0].Summary())
+	})
+
+	t.Run("LookupFailureSkipsCheck", func(t *testing.T) {
+		t.Parallel()
+		data, _ := newLookupTestData(t, respondError)
+
+		require.Empty(t, data.validateLoginType(ctx, loginTypePath, "oidc"))
+	})
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// entitlement declares that a resource, or one of its attributes, requires a
+// licensed feature.
+type entitlement struct {
+	// Feature is the feature the license must grant.
+	Feature codersdk.FeatureName
+	// Attribute is the attribute that requires the feature. The empty path
+	// means the resource itself requires it.
+	Attribute path.Path
+	// Requires reports whether a planned value of Attribute requires the
+	// feature. It is only called with known, non-null values. If nil, any
+	// non-null value requires the feature.
+	Requires func(attr.Value) bool
+	// Usage completes the sentence "Your license is not entitled to ...".
+	Usage string
+}
+
+// resourceWithEntitlements is implemented by resources that require licensed
+// features. Their ModifyPlan passes Entitlements to checkEntitlements, so an
+// unlicensed configuration fails at plan time instead of partway through an
+// apply. Their Create and Update pass it to checkAppliedEntitlements, which
+// catches what the plan-time check couldn't see.
+type resourceWithEntitlements interface {
+	resource.ResourceWithModifyPlan
+	Entitlements() []entitlement
+}
+
+// differsFrom returns a Requires function for an attribute that only requires
+// the feature when it's changed from its unlicensed default.
+func differsFrom(value attr.Value) func(attr.Value) bool {
+	return func(planned attr.Value) bool {
+		return !planned.Equal(value)
+	}
+}
+
+// checkEntitlements reports an error for each entitlement the plan requires
+// but the license doesn't grant. Unknown values are skipped, and so is the
+// whole check while a license planned in the same run may grant new features.
+func (d *CoderdProviderData) checkEntitlements(ctx context.Context, plan tfsdk.Plan, entitlements []entitlement) diag.Diagnostics {
+	// The cached features are refreshed once the license is added, and
+	// checkAppliedEntitlements checks the applied values against them.
+	if d.licensePending.Load() {
+		tflog.Debug(ctx, "license planned in the same run, skipping entitlement checks until apply")
+		return nil
+	}
+	return d.checkAppliedEntitlements(ctx, plan, entitlements)
+}
+
+// checkAppliedEntitlements reports an error for each entitlement the values
+// being applied require but the license doesn't grant. It's called by Create
+// and Update, where every value is known, so it also covers the values and
+// licenses checkEntitlements skipped at plan time.
+func (d *CoderdProviderData) checkAppliedEntitlements(ctx context.Context, plan tfsdk.Plan, entitlements []entitlement) (diags diag.Diagnostics) {
+	// A destroy plan doesn't use any features.
+	if plan.Raw.IsNull() {
+		return nil
+	}
+
+	for _, e := range entitlements {
+		if d.FeatureEnabled(e.Feature) {
+			continue
+		}
+		if e.Attribute.Equal(path.Empty()) {
+			diags.Append(d.requireEntitlement(e)...)
+			continue
+		}
+		value, valueDiags := planValue(ctx, plan, e.Attribute)
+		diags.Append(valueDiags...)
+		if valueDiags.HasError() || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if e.Requires != nil && !e.Requires(value) {
+			continue
+		}
+		diags.AddAttributeError(e.Attribute, "Feature not enabled", d.notEntitledDetail(e))
+	}
+	return diags
+}
+
+// requireEntitlement reports an error if the license doesn't grant the
+// feature of a whole-resource entitlement.
+func (d *CoderdProviderData) requireEntitlement(e entitlement) (diags diag.Diagnostics) {
+	if !d.FeatureEnabled(e.Feature) {
+		diags.AddError("Feature not enabled", d.notEntitledDetail(e))
+	}
+	return diags
+}
+
+// notEntitledDetail explains that the license doesn't grant e.
+func (d *CoderdProviderData) notEntitledDetail(e entitlement) string {
+	detail := fmt.Sprintf("Your license is not entitled to %s.", e.Usage)
+	if d.licensePending.Load() {
+		detail += " If a coderd_license in this configuration grants it, make this resource depend on the license so it's added first."
+	}
+	return detail
+}
+
+// planValue returns the planned value of the attribute at p.
+func planValue(ctx context.Context, plan tfsdk.Plan, p path.Path) (attr.Value, diag.Diagnostics) {
+	typ, diags := plan.Schema.TypeAtPath(ctx, p)
+	if diags.HasError() {
+		return nil, diags
+	}
+	// GetAttribute only accepts a target of the value's concrete type.
+	target := reflect.New(reflect.TypeOf(typ.ValueType(ctx)))
+	diags.Append(plan.GetAttribute(ctx, p, target.Interface())...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return target.Elem().Interface().(attr.Value), diags
+}
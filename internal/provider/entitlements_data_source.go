@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EntitlementsDataSource{}
+
+func NewEntitlementsDataSource() datasource.DataSource {
+	return &EntitlementsDataSource{}
+}
+
+// EntitlementsDataSource defines the data source implementation.
+type EntitlementsDataSource struct {
+	data *CoderdProviderData
+}
+
+// EntitlementsDataSourceModel describes the data source data model.
+type EntitlementsDataSourceModel struct {
+	HasLicense       types.Bool                     `tfsdk:"has_license"`
+	Trial            types.Bool                     `tfsdk:"trial"`
+	LicenseExpiresAt types.Int64                    `tfsdk:"license_expires_at"`
+	Features         map[string]EntitlementsFeature `tfsdk:"features"`
+	Warnings         []string                       `tfsdk:"warnings"`
+	Errors           []string                       `tfsdk:"errors"`
+}
+
+type EntitlementsFeature struct {
+	Entitlement types.String `tfsdk:"entitlement"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Limit       types.Int64  `tfsdk:"limit"`
+	Actual      types.Int64  `tfsdk:"actual"`
+}
+
+func (d *EntitlementsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlements"
+}
+
+func (d *EntitlementsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The features the Coder deployment's licenses grant.
+
+Resources check their configuration against these entitlements when planning, so an unlicensed setting fails before anything is applied.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"has_license": schema.BoolAttribute{
+				MarkdownDescription: "Whether the deployment has a license.",
+				Computed:            true,
+			},
+			"trial": schema.BoolAttribute{
+				MarkdownDescription: "Whether the deployment is licensed for a trial.",
+				Computed:            true,
+			},
+			"license_expires_at": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp of when the last of the deployment's licenses expires. Null if the deployment has no license.",
+				Computed:            true,
+			},
+			"features": schema.MapNestedAttribute{
+				MarkdownDescription: "The deployment's features, keyed by feature name, such as `template_rbac` or `workspace_proxy`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entitlement": schema.StringAttribute{
+							MarkdownDescription: "The entitlement to the feature. Can be `entitled`, `grace_period` or `not_entitled`.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the feature is enabled.",
+							Computed:            true,
+						},
+						"limit": schema.Int64Attribute{
+							MarkdownDescription: "The usage limit of the feature, such as the number of licensed users. Null if the feature is unlimited.",
+							Computed:            true,
+						},
+						"actual": schema.Int64Attribute{
+							MarkdownDescription: "The current usage of the feature. Null if the feature's usage isn't measured.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"warnings": schema.ListAttribute{
+				MarkdownDescription: "Warnings about the deployment's licenses, such as an upcoming expiry or exceeded user limit.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"errors": schema.ListAttribute{
+				MarkdownDescription: "Errors with the deployment's licenses.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EntitlementsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *EntitlementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EntitlementsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.data.Client
+
+	entitlements, err := client.Entitlements(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get deployment entitlements, got error: %s", err))
+		return
+	}
+	// Keep the entitlements resources check against up to date.
+	d.data.SetFeatures(entitlements.Features)
+	d.data.hasLicense.Store(entitlements.HasLicense)
+
+	data.HasLicense = types.BoolValue(entitlements.HasLicense)
+	data.Trial = types.BoolValue(entitlements.Trial)
+	data.Features = make(map[string]EntitlementsFeature, len(entitlements.Features))
+	for name, feature := range entitlements.Features {
+		data.Features[string(name)] = EntitlementsFeature{
+			Entitlement: types.StringValue(string(feature.Entitlement)),
+			Enabled:     types.BoolValue(feature.Enabled),
+			Limit:       types.Int64PointerValue(feature.Limit),
+			Actual:      types.Int64PointerValue(feature.Actual),
+		}
+	}
+	data.Warnings = append([]string{}, entitlements.Warnings...)
+	data.Errors = append([]string{}, entitlements.Errors...)
+
+	data.LicenseExpiresAt = types.Int64Null()
+	if entitlements.HasLicense {
+		licenses, err := client.Licenses(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list licenses, got error: %s", err))
+			return
+		}
+		for _, license := range licenses {
+			expiresAt, err := license.ExpiresAt()
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse license expiration, got error: %s", err))
+				return
+			}
+			if expiresAt.Unix() > data.LicenseExpiresAt.ValueInt64() {
+				data.LicenseExpiresAt = types.Int64Value(expiresAt.Unix())
+			}
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEntitlementsDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+
+	cfg := func(url, token string) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_entitlements" "test" {}
+`, url, token)
+	}
+
+	t.Run("Licensed", func(t *testing.T) {
+		t.Parallel()
+		client := integration.StartCoder(ctx, t, "entitlements_data_acc", integration.UseLicense)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg(client.URL.String(), client.SessionToken()),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "has_license", "true"),
+						resource.TestCheckResourceAttrSet("data.coderd_entitlements.test", "license_expires_at"),
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "features.template_rbac.entitlement", "entitled"),
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "features.template_rbac.enabled", "true"),
+						resource.TestCheckResourceAttrSet("data.coderd_entitlements.test", "features.user_limit.actual"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("Unlicensed", func(t *testing.T) {
+		t.Parallel()
+		client := integration.StartCoder(ctx, t, "entitlements_data_agpl_acc")
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg(client.URL.String(), client.SessionToken()),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "has_license", "false"),
+						resource.TestCheckNoResourceAttr("data.coderd_entitlements.test", "license_expires_at"),
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "features.template_rbac.entitlement", "not_entitled"),
+						resource.TestCheckResourceAttr("data.coderd_entitlements.test", "features.template_rbac.enabled", "false"),
+					),
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestResourceEntitlements(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for _, newResource := range New("test")().Resources(ctx) {
+		r, ok := newResource().(resourceWithEntitlements)
+		if !ok {
+			continue
+		}
+		meta := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "coderd"}, meta)
+		t.Run(meta.TypeName, func(t *testing.T) {
+			t.Parallel()
+			resp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, resp)
+			require.Empty(t, resp.Diagnostics)
+
+			for _, e := range r.Entitlements() {
+				require.NotEmpty(t, e.Feature)
+				require.NotEmpty(t, e.Usage)
+				if e.Attribute.Equal(path.Empty()) {
+					continue
+				}
+				_, diags := resp.Schema.TypeAtPath(ctx, e.Attribute)
+				require.False(t, diags.HasError(), "%s has no attribute %s", meta.TypeName, e.Attribute)
+			}
+		})
+	}
+}
+
+func TestCheckEntitlements(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"schedule":   schema.StringAttribute{Optional: true},
+			"auto_start": schema.BoolAttribute{Optional: true},
+		},
+	}
+	objType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"schedule":   tftypes.String,
+			"auto_start": tftypes.Bool,
+		},
+	}
+	testPlan := func(schedule any, autoStart any) tfsdk.Plan {
+		return tfsdk.Plan{
+			Schema: testSchema,
+			Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"schedule":   tftypes.NewValue(tftypes.String, schedule),
+				"auto_start": tftypes.NewValue(tftypes.Bool, autoStart),
+			}),
+		}
+	}
+	entitlements := []entitlement{
+		{
+			Feature:   codersdk.FeatureAdvancedTemplateScheduling,
+			Attribute: path.Root("schedule"),
+			Usage:     "set schedule",
+		},
+		{
+			Feature:   codersdk.FeatureAdvancedTemplateScheduling,
+			Attribute: path.Root("auto_start"),
+			Requires:  differsFrom(types.BoolValue(true)),
+			Usage:     "disable auto_start",
+		},
+	}
+
+	for _, tc := range []struct {
+		name           string
+		plan           tfsdk.Plan
+		entitlements   []entitlement
+		enabled        bool
+		licensePending bool
+		applied        bool
+		wantErrors     []path.Path
+	}{
+		{
+			name:         "NotEntitled",
+			plan:         testPlan("CRON_TZ=UTC 0 0 * * *", false),
+			entitlements: entitlements,
+			wantErrors:   []path.Path{path.Root("schedule"), path.Root("auto_start")},
+		},
+		{
+			name:         "Entitled",
+			plan:         testPlan("CRON_TZ=UTC 0 0 * * *", false),
+			entitlements: entitlements,
+			enabled:      true,
+		},
+		{
+			name:         "Defaults",
+			plan:         testPlan(nil, true),
+			entitlements: entitlements,
+		},
+		{
+			name:         "Unknown",
+			plan:         testPlan(tftypes.UnknownValue, tftypes.UnknownValue),
+			entitlements: entitlements,
+		},
+		{
+			name:         "Destroy",
+			plan:         tfsdk.Plan{Schema: testSchema, Raw: tftypes.NewValue(objType, nil)},
+			entitlements: entitlements,
+		},
+		{
+			name:           "LicensePending",
+			plan:           testPlan("CRON_TZ=UTC 0 0 * * *", false),
+			entitlements:   entitlements,
+			licensePending: true,
+		},
+		{
+			name:           "LicensePendingApplied",
+			plan:           testPlan("CRON_TZ=UTC 0 0 * * *", false),
+			entitlements:   entitlements,
+			licensePending: true,
+			applied:        true,
+			wantErrors:     []path.Path{path.Root("schedule"), path.Root("auto_start")},
+		},
+		{
+			name: "Resource",
+			plan: testPlan(nil, true),
+			entitlements: []entitlement{{
+				Feature: codersdk.FeatureTemplateRBAC,
+				Usage:   "use groups",
+			}},
+			wantErrors: []path.Path{path.Empty()},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			data := &CoderdProviderData{}
+			data.SetFeatures(map[codersdk.FeatureName]codersdk.Feature{
+				codersdk.FeatureAdvancedTemplateScheduling: {Enabled: tc.enabled},
+				codersdk.FeatureTemplateRBAC:               {Enabled: tc.enabled},
+			})
+			data.licensePending.Store(tc.licensePending)
+
+			check := data.checkEntitlements
+			if tc.applied {
+				check = data.checkAppliedEntitlements
+			}
+			diags := check(ctx, tc.plan, tc.entitlements)
+			require.Len(t, diags.Errors(), len(tc.wantErrors))
+			for i, d := range diags.Errors() {
+				require.Equal(t, "Feature not enabled", d.Summary())
+				if tc.wantErrors[i].Equal(path.Empty()) {
+					continue
+				}
+				withPath, ok := d.(interface{ Path() path.Path })
+				require.True(t, ok)
+				require.True(t, withPath.Path().Equal(tc.wantErrors[i]))
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ExternalAuthProvidersDataSource{}
+
+func NewExternalAuthProvidersDataSource() datasource.DataSource {
+	return &ExternalAuthProvidersDataSource{}
+}
+
+// ExternalAuthProvidersDataSource defines the data source implementation.
+type ExternalAuthProvidersDataSource struct {
+	data *CoderdProviderData
+}
+
+// ExternalAuthProvidersDataSourceModel describes the data source data model.
+type ExternalAuthProvidersDataSourceModel struct {
+	Providers []ExternalAuthProvider `tfsdk:"providers"`
+}
+
+type ExternalAuthProvider struct {
+	ID            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	DisplayIcon   types.String `tfsdk:"display_icon"`
+	Device        types.Bool   `tfsdk:"device"`
+	AllowRefresh  types.Bool   `tfsdk:"allow_refresh"`
+	AllowValidate types.Bool   `tfsdk:"allow_validate"`
+}
+
+func (d *ExternalAuthProvidersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_auth_providers"
+}
+
+func (d *ExternalAuthProvidersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The external auth providers, such as GitHub or GitLab, configured on the Coder deployment.
+
+Templates reference these providers by ID in ` + "`coder_external_auth`" + ` data sources. A template version that references a provider the deployment doesn't have can't be used to build workspaces.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"providers": schema.ListNestedAttribute{
+				MarkdownDescription: "The configured providers.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID templates reference the provider by.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the provider, such as `github`, `gitlab` or `azure-devops`.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"display_icon": schema.StringAttribute{
+							Computed: true,
+						},
+						"device": schema.BoolAttribute{
+							MarkdownDescription: "Whether users link the provider with the device flow.",
+							Computed:            true,
+						},
+						"allow_refresh": schema.BoolAttribute{
+							MarkdownDescription: "Whether Coder refreshes expired tokens from the provider.",
+							Computed:            true,
+						},
+						"allow_validate": schema.BoolAttribute{
+							MarkdownDescription: "Whether Coder validates tokens with the provider.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ExternalAuthProvidersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *ExternalAuthProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExternalAuthProvidersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	externalAuths, err := d.data.Client.ListExternalAuths(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list external auth providers, got error: %s", err))
+		return
+	}
+
+	providers := make([]ExternalAuthProvider, 0, len(externalAuths.Providers))
+	for _, provider := range externalAuths.Providers {
+		providers = append(providers, ExternalAuthProvider{
+			ID:            types.StringValue(provider.ID),
+			Type:          types.StringValue(provider.Type),
+			DisplayName:   types.StringValue(provider.DisplayName),
+			DisplayIcon:   types.StringValue(provider.DisplayIcon),
+			Device:        types.BoolValue(provider.Device),
+			AllowRefresh:  types.BoolValue(provider.AllowRefresh),
+			AllowValidate: types.BoolValue(provider.AllowValidate),
+		})
+	}
+	data.Providers = providers
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccExternalAuthEnv configures a GitHub external auth provider on the
+// test deployment. Nothing ever authenticates with it.
+var testAccExternalAuthEnv = map[string]string{
+	"CODER_EXTERNAL_AUTH_0_ID":            "github",
+	"CODER_EXTERNAL_AUTH_0_TYPE":          "github",
+	"CODER_EXTERNAL_AUTH_0_CLIENT_ID":     "fake-client-id",
+	"CODER_EXTERNAL_AUTH_0_CLIENT_SECRET": "fake-client-secret",
+}
+
+func TestAccExternalAuthProvidersDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "external_auth_providers_data_acc", integration.CoderEnv(testAccExternalAuthEnv))
+
+	cfg := fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_external_auth_providers" "test" {}
+`, client.URL.String(), client.SessionToken())
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_external_auth_providers.test", "providers.#", "1"),
+					resource.TestCheckResourceAttr("data.coderd_external_auth_providers.test", "providers.0.id", "github"),
+					resource.TestCheckResourceAttr("data.coderd_external_auth_providers.test", "providers.0.type", "github"),
+					resource.TestCheckResourceAttr("data.coderd_external_auth_providers.test", "providers.0.device", "false"),
+				),
+			},
+		},
+	})
+}
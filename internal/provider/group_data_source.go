@@ -2,15 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -35,6 +40,7 @@ type GroupDataSourceModel struct {
 	DisplayName    types.String `tfsdk:"display_name"`
 	AvatarURL      types.String `tfsdk:"avatar_url"`
 	QuotaAllowance types.Int32  `tfsdk:"quota_allowance"`
+	QuotaUsed      types.Int64  `tfsdk:"quota_used"`
 	Source         types.String `tfsdk:"source"`
 	Members        []Member     `tfsdk:"members"`
 }
@@ -91,6 +97,10 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "The number of quota credits to allocate to each user in the group.",
 				Computed:            true,
 			},
+			"quota_used": schema.Int64Attribute{
+				MarkdownDescription: "The number of quota credits consumed by the group's members, summed across members. A member's consumption counts in full towards every group they belong to, and is not limited to the credits this group allocates. Null if the license doesn't include workspace quotas or the provider's token can't read the members' quotas, and null with a warning if reading them fails for another reason.",
+				Computed:            true,
+			},
 			"source": schema.StringAttribute{
 				MarkdownDescription: "The source of the group. Either `oidc` or `user`.",
 				Computed:            true,
@@ -164,7 +174,7 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(CheckGroupEntitlements(ctx, d.data.Features())...)
+	resp.Diagnostics.Append(d.data.requireEntitlement(groupsEntitlement)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -223,8 +233,44 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		})
 	}
 	data.Members = members
+
+	memberIDs := make([]uuid.UUID, 0, len(group.Members))
+	for _, member := range group.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	quotaUsed, diags := d.quotaUsed(ctx, group.OrganizationID, memberIDs)
+	resp.Diagnostics.Append(diags...)
+	data.QuotaUsed = quotaUsed
 	data.Source = types.StringValue(string(group.Source))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// quotaUsed sums the credits consumed by the members. Quotas need a license
+// and permission to read members' quotas, so without either it's null, and
+// any other failed lookup leaves it null with a warning rather than failing
+// the read.
+func (d *GroupDataSource) quotaUsed(ctx context.Context, organizationID uuid.UUID, memberIDs []uuid.UUID) (types.Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !d.data.FeatureEnabled(workspaceQuotaEntitlement.Feature) {
+		return types.Int64Null(), diags
+	}
+	quotas, err := workspaceQuotas(ctx, d.data.Client, organizationID, memberIDs)
+	if err != nil {
+		var sdkErr *codersdk.Error
+		if errors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusForbidden {
+			tflog.Debug(ctx, "not permitted to read workspace quotas, leaving quota_used null", map[string]any{
+				"error": err.Error(),
+			})
+			return types.Int64Null(), diags
+		}
+		diags.AddWarning("Client Warning", fmt.Sprintf("Unable to get workspace quotas of group members, so `quota_used` is null: %s", err))
+		return types.Int64Null(), diags
+	}
+	var quotaUsed int64
+	for _, quota := range quotas {
+		quotaUsed += int64(quota.CreditsConsumed)
+	}
+	return types.Int64Value(quotaUsed), diags
+}
@@ -61,6 +61,7 @@ func TestAccGroupDataSource(t *testing.T) {
 		resource.TestCheckResourceAttr("data.coderd_group.test", "display_name", "Example Group"),
 		resource.TestCheckResourceAttr("data.coderd_group.test", "avatar_url", "https://google.com"),
 		resource.TestCheckResourceAttr("data.coderd_group.test", "quota_allowance", "10"),
+		resource.TestCheckResourceAttr("data.coderd_group.test", "quota_used", "0"),
 		resource.TestCheckResourceAttr("data.coderd_group.test", "members.#", "2"),
 		resource.TestCheckTypeSetElemNestedAttrs("data.coderd_group.test", "members.*", map[string]string{
 			"id": user1.ID.String(),
@@ -9,7 +9,6 @@ import (
 	"github.com/coder/terraform-provider-coderd/internal/codersdkvalidator"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,6 +24,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupResource{}
 var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
+var _ resourceWithEntitlements = &GroupResource{}
 
 func NewGroupResource() resource.Resource {
 	return &GroupResource{}
@@ -47,12 +48,15 @@ type GroupResourceModel struct {
 	Members        types.Set    `tfsdk:"members"`
 }
 
-func CheckGroupEntitlements(ctx context.Context, features map[codersdk.FeatureName]codersdk.Feature) (diags diag.Diagnostics) {
-	if !features[codersdk.FeatureTemplateRBAC].Enabled {
-		diags.AddError("Feature not enabled", "Your license is not entitled to use groups.")
-		return
-	}
-	return nil
+// groupsEntitlement is required by both the group resource and data source.
+var groupsEntitlement = entitlement{
+	Feature: codersdk.FeatureTemplateRBAC,
+	Usage:   "use groups",
+}
+
+// Entitlements implements resourceWithEntitlements.
+func (r *GroupResource) Entitlements() []entitlement {
+	return []entitlement{groupsEntitlement}
 }
 
 func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -97,7 +101,7 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			// Int32 in the db
 			"quota_allowance": schema.Int32Attribute{
-				MarkdownDescription: "The number of quota credits to allocate to each user in the group.",
+				MarkdownDescription: "The number of quota credits to allocate to each user in the group. A warning is emitted at plan time if lowering it would leave members using more credits than their budget.",
 				Optional:            true,
 				Computed:            true,
 				Default:             int32default.StaticInt32(0),
@@ -140,6 +144,70 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.data = data
 }
 
+// ModifyPlan checks the license, and warns when lowering quota_allowance would
+// leave members using more quota credits than their budget allows.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Configure() has not run during the validate walk.
+	if r.data == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.data.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+	// Only updates to an existing group can lower its allowance.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state GroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.QuotaAllowance.IsUnknown() {
+		return
+	}
+	reduction := int(state.QuotaAllowance.ValueInt32() - plan.QuotaAllowance.ValueInt32())
+	if reduction <= 0 {
+		return
+	}
+
+	client := r.data.Client
+	group, err := client.Group(ctx, state.ID.ValueUUID(), codersdk.GroupRequest{})
+	if err != nil {
+		tflog.Debug(ctx, "unable to get group, skipping quota check", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	memberIDs := make([]uuid.UUID, 0, len(group.Members))
+	for _, member := range group.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	quotas, err := workspaceQuotas(ctx, client, group.OrganizationID, memberIDs)
+	if err != nil {
+		tflog.Debug(ctx, "unable to get workspace quotas, skipping quota check", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var sb strings.Builder
+	for _, member := range group.Members {
+		quota := quotas[member.ID]
+		budget := quota.Budget - reduction
+		if quota.CreditsConsumed <= budget {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n  - %s uses %d credits, and their budget drops from %d to %d", member.Username, quota.CreditsConsumed, quota.Budget, budget)
+	}
+	if sb.Len() == 0 {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("quota_allowance"),
+		"Quota Allowance Below Consumption",
+		fmt.Sprintf("Lowering quota_allowance from %d to %d leaves members over budget. "+
+			"They won't be able to start workspaces until their usage drops:%s",
+			state.QuotaAllowance.ValueInt32(), plan.QuotaAllowance.ValueInt32(), sb.String()),
+	)
+}
+
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data GroupResourceModel
 
@@ -150,7 +218,7 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(CheckGroupEntitlements(ctx, r.data.Features())...)
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -253,6 +321,11 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client := r.data.Client
 	if data.OrganizationID.IsUnknown() {
 		data.OrganizationID = UUIDValue(r.data.DefaultOrganizationID)
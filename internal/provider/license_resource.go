@@ -2,20 +2,41 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/coder/coder/v2/codersdk"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LicenseResource{}
+var _ resource.ResourceWithImportState = &LicenseResource{}
+var _ resource.ResourceWithModifyPlan = &LicenseResource{}
+
+// defaultLicenseWarnBeforeExpiry is 30 days.
+const defaultLicenseWarnBeforeExpiry = "720h"
+
+// licenseUUIDKey is the private state key holding the UUID of the license
+// last read from the deployment. The license key itself can't be read back,
+// so after an import this is how a configured key is matched to the license.
+const licenseUUIDKey = "license_uuid"
 
 func NewLicenseResource() resource.Resource {
 	return &LicenseResource{}
@@ -28,9 +49,10 @@ type LicenseResource struct {
 
 // LicenseResourceModel describes the resource data model.
 type LicenseResourceModel struct {
-	ID        types.Int32  `tfsdk:"id"`
-	ExpiresAt types.Int64  `tfsdk:"expires_at"`
-	License   types.String `tfsdk:"license"`
+	ID               types.Int32  `tfsdk:"id"`
+	ExpiresAt        types.Int64  `tfsdk:"expires_at"`
+	License          types.String `tfsdk:"license"`
+	WarnBeforeExpiry types.String `tfsdk:"warn_before_expiry"`
 }
 
 func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -39,11 +61,9 @@ func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataReq
 
 func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "A license for a Coder deployment.\n\nIt's recommended to set " +
-			"[`create_before_destroy`](https://developer.hashicorp.com/terraform/language/meta-arguments/lifecycle#create_before_destroy) " +
-			"on license resources. Without setting this, Terraform will remove the old " +
-			"license before adding the updated license. This will result in a temporary " +
-			"disruption to your users; during which they may be unable to use features " +
+		MarkdownDescription: "A license for a Coder deployment.\n\n" +
+			"Changing `license` adds the new license before deleting the old one, so " +
+			"the deployment stays licensed throughout and users can keep using features " +
 			"that require a license.\n\n" +
 			"Terraform does not guarantee this resource " +
 			"will be created before other resources or attributes that require a licensed deployment. " +
@@ -60,13 +80,22 @@ func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"expires_at": schema.Int64Attribute{
 				MarkdownDescription: "Unix timestamp of when the license expires.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"license": schema.StringAttribute{
 				MarkdownDescription: "A license key for Coder.",
 				Required:            true,
 				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			},
+			"warn_before_expiry": schema.StringAttribute{
+				MarkdownDescription: "How long before the license expires to warn about it when planning, as a Go duration string such as `720h`. Set to `0s` to only warn once the license has expired. Defaults to `720h` (30 days).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultLicenseWarnBeforeExpiry),
+				Validators: []validator.String{
+					durationValidator{},
 				},
 			},
 		},
@@ -93,39 +122,87 @@ func (r *LicenseResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.data = data
 }
 
-func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data LicenseResourceModel
+// ModifyPlan warns when the license has expired or expires soon. When a
+// license will be added, it also marks the ID and expiry as unknown. If the
+// license may grant different features, it records that resources depending
+// on it shouldn't be checked against the entitlements from before it's added.
+func (r *LicenseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.data == nil {
+		return
+	}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	var plan LicenseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *LicenseResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &LicenseResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
+	changed, diags := licenseChanged(ctx, req.Private, state, plan.License)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := r.data.Client
+	var expiresAt time.Time
+	if changed {
+		if licenseMayChangeFeatures(r.data.hasLicense.Load(), state, plan.License) {
+			r.data.licensePending.Store(true)
+		}
+		if state != nil {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.Int32Unknown())...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.Int64Unknown())...)
+		}
+		if plan.License.IsUnknown() {
+			return
+		}
+		claims, err := parseLicenseClaims(plan.License.ValueString())
+		if err != nil || claims.LicenseExpires == 0 {
+			// The deployment reports invalid licenses when they're added.
+			tflog.Debug(ctx, "unable to read license expiry, skipping expiry check", map[string]any{
+				"error": fmt.Sprint(err),
+			})
+			return
+		}
+		expiresAt = time.Unix(int64(claims.LicenseExpires), 0)
+	} else {
+		expiresAt = time.Unix(state.ExpiresAt.ValueInt64(), 0)
+	}
 
-	license, err := client.AddLicense(ctx, codersdk.AddLicenseRequest{
-		License: data.License.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add license, got error: %s", err))
+	if plan.WarnBeforeExpiry.IsUnknown() {
 		return
 	}
-	data.ID = types.Int32Value(license.ID)
-	expiresAt, err := license.ExpiresAt()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse license expiration, got error: %s", err))
+	// Validated at plan time by durationValidator.
+	warnBefore, _ := time.ParseDuration(plan.WarnBeforeExpiry.ValueString())
+	resp.Diagnostics.Append(licenseExpiryWarning(expiresAt, warnBefore, time.Now())...)
+}
+
+func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LicenseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.ExpiresAt = types.Int64Value(expiresAt.Unix())
 
-	entitlements, err := client.Entitlements(ctx)
-	if err != nil {
-		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Unable to refresh deployment entitlements after adding license, got error: %s", err))
-	} else {
-		r.data.SetFeatures(entitlements.Features)
+	resp.Diagnostics.Append(r.add(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	resp.Diagnostics.Append(r.refreshFeatures(ctx, "adding")...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -157,6 +234,13 @@ func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 				return
 			}
 			data.ExpiresAt = types.Int64Value(expiresAt.Unix())
+
+			licenseUUID, err := json.Marshal(license.UUID.String())
+			if err != nil {
+				resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to encode license UUID, got error: %s", err))
+				return
+			}
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, licenseUUIDKey, licenseUUID)...)
 		}
 	}
 	if !found {
@@ -170,16 +254,39 @@ func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data LicenseResourceModel
+	var data, state LicenseResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	changed, diags := licenseChanged(ctx, req.Private, &state, data.License)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if !changed {
+		data.ID = state.ID
+		data.ExpiresAt = state.ExpiresAt
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
-	// Update is handled by replacement
+	// Add the new license before deleting the old one, so the deployment
+	// stays licensed throughout.
+	resp.Diagnostics.Append(r.add(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.data.Client.DeleteLicense(ctx, state.ID.ValueInt32())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Added license %d, but unable to delete license %d it replaces, got error: %s", data.ID.ValueInt32(), state.ID.ValueInt32(), err))
+	}
+	resp.Diagnostics.Append(r.refreshFeatures(ctx, "replacing")...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -203,10 +310,156 @@ func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	entitlements, err := client.Entitlements(ctx)
+	resp.Diagnostics.Append(r.refreshFeatures(ctx, "deleting")...)
+}
+
+func (r *LicenseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 32)
 	if err != nil {
-		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Unable to refresh deployment entitlements after deleting license, got error: %s", err))
-	} else {
-		r.data.SetFeatures(entitlements.Features)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse import license ID as an integer, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int32(id))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("warn_before_expiry"), defaultLicenseWarnBeforeExpiry)...)
+}
+
+// add adds the license in data to the deployment and fills in its computed
+// attributes.
+func (r *LicenseResource) add(ctx context.Context, data *LicenseResourceModel) (diags diag.Diagnostics) {
+	license, err := r.data.Client.AddLicense(ctx, codersdk.AddLicenseRequest{
+		License: data.License.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to add license, got error: %s", err))
+		return diags
+	}
+	data.ID = types.Int32Value(license.ID)
+	expiresAt, err := license.ExpiresAt()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse license expiration, got error: %s", err))
+		return diags
+	}
+	data.ExpiresAt = types.Int64Value(expiresAt.Unix())
+	return diags
+}
+
+// refreshFeatures updates the cached entitlements once the deployment's
+// licenses have changed. action describes the change, such as "adding".
+func (r *LicenseResource) refreshFeatures(ctx context.Context, action string) (diags diag.Diagnostics) {
+	entitlements, err := r.data.Client.Entitlements(ctx)
+	if err != nil {
+		diags.AddWarning("Client Warning", fmt.Sprintf("Unable to refresh deployment entitlements after %s license, got error: %s", action, err))
+		return diags
+	}
+	r.data.SetFeatures(entitlements.Features)
+	r.data.hasLicense.Store(entitlements.HasLicense)
+	r.data.licensePending.Store(false)
+	return diags
+}
+
+// licenseChanged reports whether license is a different license to the one in
+// state, which is nil when the license is being created. An imported license
+// has no key in state, so its UUID is compared to the key's instead.
+func licenseChanged(ctx context.Context, ps privateState, state *LicenseResourceModel, license types.String) (bool, diag.Diagnostics) {
+	if state == nil || license.IsUnknown() {
+		return true, nil
+	}
+	if !state.License.IsNull() {
+		return !state.License.Equal(license), nil
+	}
+
+	raw, diags := ps.GetKey(ctx, licenseUUIDKey)
+	if diags.HasError() || raw == nil {
+		return true, diags
+	}
+	var licenseUUID string
+	if err := json.Unmarshal(raw, &licenseUUID); err != nil {
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to decode license UUID from private state, got error: %s", err))
+		return true, diags
+	}
+	claims, err := parseLicenseClaims(license.ValueString())
+	if err != nil {
+		return true, diags
+	}
+	return claims.ID != licenseUUID, diags
+}
+
+// licenseMayChangeFeatures reports whether adding license may change the
+// features the deployment grants. A renewal whose feature claims match the
+// license it replaces doesn't, so resources depending on it are still checked
+// against the cached entitlements.
+func licenseMayChangeFeatures(hasLicense bool, state *LicenseResourceModel, license types.String) bool {
+	if !hasLicense || state == nil || state.License.IsNull() || license.IsUnknown() {
+		return true
+	}
+	current, err := parseLicenseClaims(state.License.ValueString())
+	if err != nil {
+		return true
+	}
+	planned, err := parseLicenseClaims(license.ValueString())
+	if err != nil {
+		return true
+	}
+	return !current.sameFeatures(planned)
+}
+
+// licenseClaims are the claims of a license key that the provider reads when
+// planning. They aren't verified here; the deployment verifies a license when
+// it's added.
+type licenseClaims struct {
+	// ID becomes the UUID of the license once it's added.
+	ID string `json:"jti"`
+	// LicenseExpires is a Unix timestamp, or zero if unset.
+	LicenseExpires float64 `json:"license_expires"`
+	// Features, AllFeatures and FeatureSet are the features the license
+	// grants.
+	Features    map[string]int64 `json:"features"`
+	AllFeatures bool             `json:"all_features"`
+	FeatureSet  string           `json:"feature_set"`
+}
+
+// sameFeatures reports whether c and o grant the same features.
+func (c licenseClaims) sameFeatures(o licenseClaims) bool {
+	return c.AllFeatures == o.AllFeatures && c.FeatureSet == o.FeatureSet && maps.Equal(c.Features, o.Features)
+}
+
+// parseLicenseClaims reads the claims of a license key, which is a JWT.
+func parseLicenseClaims(license string) (licenseClaims, error) {
+	var claims licenseClaims
+	parts := strings.Split(strings.TrimSpace(license), ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("license is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, fmt.Errorf("decode license claims: %w", err)
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, fmt.Errorf("unmarshal license claims: %w", err)
+	}
+	return claims, nil
+}
+
+// licenseExpiryWarning warns when a license has expired, or expires within
+// warnBefore of now.
+func licenseExpiryWarning(expiresAt time.Time, warnBefore time.Duration, now time.Time) (diags diag.Diagnostics) {
+	date := expiresAt.UTC().Format(time.DateOnly)
+	remaining := expiresAt.Sub(now)
+	switch {
+	case remaining <= 0:
+		diags.AddAttributeWarning(path.Root("license"), "License Expired",
+			fmt.Sprintf("The license expired on %s. Features it grants stop working once its grace period ends. Upload a renewed license.", date))
+	case remaining <= warnBefore:
+		days := int(remaining.Hours() / 24)
+		in := fmt.Sprintf("in %d days", days)
+		switch days {
+		case 0:
+			in = "in less than a day"
+		case 1:
+			in = "in 1 day"
+		}
+		diags.AddAttributeWarning(path.Root("license"), "License Expiring Soon",
+			fmt.Sprintf("The license expires on %s, %s. Upload a renewed license before then to keep using the features it grants.", date, in))
 	}
+	return diags
 }
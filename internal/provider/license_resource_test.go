@@ -1,13 +1,21 @@
 package provider
 
 import (
+	"encoding/base64"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,11 +45,165 @@ func TestAccLicenseResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: cfg1.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderd_license.test", "id"),
+					resource.TestCheckResourceAttrSet("coderd_license.test", "expires_at"),
+					resource.TestCheckResourceAttr("coderd_license.test", "warn_before_expiry", "720h"),
+				),
+			},
+			// Import by ID, without the license key.
+			{
+				Config:                  cfg1.String(t),
+				ResourceName:            "coderd_license.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"license"},
+				ImportStatePersist:      true,
+			},
+			// The imported license matches the configured key, so it's
+			// updated in place rather than added again.
+			{
+				Config: cfg1.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_license.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("coderd_license.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					licenses, err := client.Licenses(ctx)
+					if err != nil {
+						return err
+					}
+					if len(licenses) != 1 {
+						return fmt.Errorf("expected 1 license, got %d", len(licenses))
+					}
+					return nil
+				},
 			},
 		},
 	})
 }
 
+func TestParseLicenseClaims(t *testing.T) {
+	t.Parallel()
+
+	encode := func(claims string) string {
+		return "eyJhbGciOiJFZERTQSJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+
+	claims, err := parseLicenseClaims(encode(`{"jti":"0b3b4bd5-8ff4-4a4c-a8d2-7a3f4e8f0c1a","license_expires":1767225600}`))
+	require.NoError(t, err)
+	require.Equal(t, "0b3b4bd5-8ff4-4a4c-a8d2-7a3f4e8f0c1a", claims.ID)
+	require.EqualValues(t, 1767225600, claims.LicenseExpires)
+
+	_, err = parseLicenseClaims("not-a-license")
+	require.Error(t, err)
+	_, err = parseLicenseClaims("a.!!!.c")
+	require.Error(t, err)
+}
+
+func TestLicenseMayChangeFeatures(t *testing.T) {
+	t.Parallel()
+
+	encode := func(claims string) types.String {
+		return types.StringValue("eyJhbGciOiJFZERTQSJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl")
+	}
+	current := encode(`{"jti":"a","feature_set":"premium","features":{"user_limit":100}}`)
+	state := &LicenseResourceModel{License: current}
+
+	for _, tc := range []struct {
+		name       string
+		hasLicense bool
+		state      *LicenseResourceModel
+		license    types.String
+		want       bool
+	}{
+		{
+			name:       "Renewal",
+			hasLicense: true,
+			state:      state,
+			license:    encode(`{"jti":"b","feature_set":"premium","features":{"user_limit":100}}`),
+		},
+		{
+			name:       "DifferentFeatures",
+			hasLicense: true,
+			state:      state,
+			license:    encode(`{"jti":"b","feature_set":"premium","features":{"user_limit":200}}`),
+			want:       true,
+		},
+		{
+			name:       "DifferentFeatureSet",
+			hasLicense: true,
+			state:      state,
+			license:    encode(`{"jti":"b","feature_set":"enterprise","features":{"user_limit":100}}`),
+			want:       true,
+		},
+		{
+			name:    "NoLicense",
+			state:   state,
+			license: encode(`{"jti":"b","feature_set":"premium","features":{"user_limit":100}}`),
+			want:    true,
+		},
+		{
+			name:       "Create",
+			hasLicense: true,
+			license:    current,
+			want:       true,
+		},
+		{
+			name:       "Imported",
+			hasLicense: true,
+			state:      &LicenseResourceModel{License: types.StringNull()},
+			license:    current,
+			want:       true,
+		},
+		{
+			name:       "Unknown",
+			hasLicense: true,
+			state:      state,
+			license:    types.StringUnknown(),
+			want:       true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, licenseMayChangeFeatures(tc.hasLicense, tc.state, tc.license))
+		})
+	}
+}
+
+func TestLicenseExpiryWarning(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	const month = 30 * 24 * time.Hour
+	for _, tc := range []struct {
+		name       string
+		expiresAt  time.Time
+		warnBefore time.Duration
+		want       string
+	}{
+		{name: "Distant", expiresAt: now.Add(2 * month), warnBefore: month},
+		{name: "Soon", expiresAt: now.Add(10 * 24 * time.Hour), warnBefore: month, want: "in 10 days"},
+		{name: "Tomorrow", expiresAt: now.Add(36 * time.Hour), warnBefore: month, want: "in 1 day"},
+		{name: "Today", expiresAt: now.Add(time.Hour), warnBefore: month, want: "in less than a day"},
+		{name: "Disabled", expiresAt: now.Add(time.Hour), warnBefore: 0},
+		{name: "Expired", expiresAt: now.Add(-time.Hour), warnBefore: 0, want: "expired on 2025-12-31"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			diags := licenseExpiryWarning(tc.expiresAt, tc.warnBefore, now)
+			if tc.want == "" {
+				require.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			require.Contains(t, diags[0].Detail(), tc.want)
+		})
+	}
+}
+
 type testAccLicenseResourceConfig struct {
 	URL     string
 	Token   string
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mcpProtocolVersion is the MCP revision requested when verifying a server.
+// Servers may answer with an older revision they support.
+const mcpProtocolVersion = "2025-06-18"
+
+// mcpMaxToolPages bounds tools/list pagination against servers that never
+// stop returning a cursor.
+const mcpMaxToolPages = 100
+
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int   `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type jsonRPCResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+type sseEvent struct {
+	Event string
+	Data  string
+}
+
+// mcpClient is the minimal MCP client needed to initialize a session and
+// list tools over the streamable HTTP or legacy SSE transport.
+type mcpClient struct {
+	http     *http.Client
+	headers  http.Header
+	endpoint string
+	nextID   int
+
+	// Streamable HTTP only.
+	sessionID       string
+	protocolVersion string
+
+	// Legacy SSE only: responses arrive on the event stream instead of in
+	// the POST response.
+	events <-chan sseEvent
+}
+
+// listMCPTools connects to the MCP server at serverURL with the given
+// transport ("streamable_http" or "sse"), initializes a session, and returns
+// the names of the server's tools. version is the provider version the client
+// reports to the server.
+func listMCPTools(ctx context.Context, httpClient *http.Client, version, transport, serverURL string, headers http.Header) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := &mcpClient{http: httpClient, headers: headers, endpoint: serverURL}
+	if transport == "sse" {
+		if err := c.connectSSE(ctx, serverURL); err != nil {
+			return nil, err
+		}
+	}
+
+	var initResult struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	err := c.call(ctx, "initialize", map[string]any{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo": map[string]any{
+			"name":    "terraform-provider-coderd",
+			"version": version,
+		},
+	}, &initResult)
+	if err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	c.protocolVersion = initResult.ProtocolVersion
+	if err := c.notify(ctx, "notifications/initialized"); err != nil {
+		return nil, fmt.Errorf("initialized notification: %w", err)
+	}
+	defer c.close(ctx)
+
+	tools := []string{}
+	cursor := ""
+	for range mcpMaxToolPages {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		var page struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := c.call(ctx, "tools/list", params, &page); err != nil {
+			return nil, fmt.Errorf("list tools: %w", err)
+		}
+		for _, tool := range page.Tools {
+			tools = append(tools, tool.Name)
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+	return tools, nil
+}
+
+// connectSSE opens the legacy SSE event stream and waits for the endpoint
+// event naming where to POST messages.
+func (c *mcpClient) connectSSE(ctx context.Context, serverURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL, nil)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return newVerifyHTTPError(resp)
+	}
+
+	events := make(chan sseEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		_ = readSSE(resp.Body, func(event sseEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	c.events = events
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("event stream closed before the endpoint event")
+			}
+			if event.Event != "endpoint" {
+				continue
+			}
+			base, err := url.Parse(serverURL)
+			if err != nil {
+				return err
+			}
+			endpoint, err := base.Parse(strings.TrimSpace(event.Data))
+			if err != nil {
+				return fmt.Errorf("invalid endpoint %q: %w", event.Data, err)
+			}
+			c.endpoint = endpoint.String()
+			return nil
+		}
+	}
+}
+
+// call sends a JSON-RPC request and decodes its result into result.
+func (c *mcpClient) call(ctx context.Context, method string, params, result any) error {
+	c.nextID++
+	id := c.nextID
+	resp, err := c.post(ctx, jsonRPCRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp *jsonRPCResponse
+	switch {
+	case c.events != nil:
+		rpcResp, err = c.awaitEvent(ctx, id)
+	case strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"):
+		rpcResp, err = awaitSSEResponse(resp.Body, id)
+	default:
+		rpcResp = &jsonRPCResponse{}
+		err = json.NewDecoder(resp.Body).Decode(rpcResp)
+	}
+	if err != nil {
+		return err
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// notify sends a JSON-RPC notification, which has no response.
+func (c *mcpClient) notify(ctx context.Context, method string) error {
+	resp, err := c.post(ctx, jsonRPCRequest{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+func (c *mcpClient) post(ctx context.Context, msg jsonRPCRequest) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, newVerifyHTTPError(resp)
+	}
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		c.sessionID = sessionID
+	}
+	return resp, nil
+}
+
+func (c *mcpClient) setHeaders(req *http.Request) {
+	for name, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if c.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", c.sessionID)
+	}
+	if c.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", c.protocolVersion)
+	}
+}
+
+// close ends a streamable HTTP session. Servers may not support explicit
+// termination, so failures are ignored.
+func (c *mcpClient) close(ctx context.Context) {
+	if c.sessionID == "" || c.events != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.endpoint, nil)
+	if err != nil {
+		return
+	}
+	c.setHeaders(req)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// awaitEvent waits on the legacy SSE stream for the response to request id.
+func (c *mcpClient) awaitEvent(ctx context.Context, id int) (*jsonRPCResponse, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-c.events:
+			if !ok {
+				return nil, errors.New("event stream closed before the response")
+			}
+			if resp, ok := parseSSEResponse(event, id); ok {
+				return resp, nil
+			}
+		}
+	}
+}
+
+// awaitSSEResponse reads a streamable HTTP response body in SSE format until
+// the response to request id.
+func awaitSSEResponse(r io.Reader, id int) (*jsonRPCResponse, error) {
+	var found *jsonRPCResponse
+	err := readSSE(r, func(event sseEvent) bool {
+		resp, ok := parseSSEResponse(event, id)
+		if ok {
+			found = resp
+		}
+		return !ok
+	})
+	if found != nil {
+		return found, nil
+	}
+	if err == nil {
+		err = errors.New("stream ended before the response")
+	}
+	return nil, err
+}
+
+// parseSSEResponse returns the JSON-RPC response carried by event if it
+// answers request id. Servers may interleave other requests and
+// notifications, which are skipped.
+func parseSSEResponse(event sseEvent, id int) (*jsonRPCResponse, bool) {
+	if event.Event != "message" {
+		return nil, false
+	}
+	var resp jsonRPCResponse
+	if err := json.Unmarshal([]byte(event.Data), &resp); err != nil {
+		return nil, false
+	}
+	if resp.ID == nil || *resp.ID != id {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// readSSE parses a server-sent event stream, calling yield for each event
+// until it returns false or the stream ends.
+func readSSE(r io.Reader, yield func(sseEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	// Tool listings can be large, and each arrives as a single data line.
+	scanner.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	var event sseEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				if !yield(event) {
+					return nil
+				}
+			}
+			event = sseEvent{}
+			data = nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
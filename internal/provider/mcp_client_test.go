@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMCPServer answers initialize and tools/list, paging its tools one per
+// response.
+type fakeMCPServer struct {
+	tools []string
+	// apiKey, if set, is required in the X-API-Key header.
+	apiKey string
+	// sseResponses answers streamable HTTP requests with an event stream
+	// instead of JSON.
+	sseResponses bool
+	// clientVersion, if set, is required as the client version in initialize.
+	clientVersion string
+}
+
+func (f fakeMCPServer) authorized(r *http.Request) bool {
+	return f.apiKey == "" || r.Header.Get("X-API-Key") == f.apiKey
+}
+
+// handle returns the response to msg, or nil for notifications.
+func (f fakeMCPServer) handle(msg jsonRPCRequest, params json.RawMessage) map[string]any {
+	if msg.ID == nil {
+		return nil
+	}
+	resp := map[string]any{"jsonrpc": "2.0", "id": *msg.ID}
+	switch msg.Method {
+	case "initialize":
+		var p struct {
+			ClientInfo struct {
+				Version string `json:"version"`
+			} `json:"clientInfo"`
+		}
+		_ = json.Unmarshal(params, &p)
+		if f.clientVersion != "" && p.ClientInfo.Version != f.clientVersion {
+			resp["error"] = map[string]any{"code": -32602, "message": "unexpected client version " + p.ClientInfo.Version}
+			break
+		}
+		resp["result"] = map[string]any{"protocolVersion": mcpProtocolVersion, "capabilities": map[string]any{}}
+	case "tools/list":
+		var p struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(params, &p)
+		page := 0
+		if p.Cursor != "" {
+			_, _ = fmt.Sscanf(p.Cursor, "page-%d", &page)
+		}
+		result := map[string]any{"tools": []map[string]any{}}
+		if page < len(f.tools) {
+			result["tools"] = []map[string]any{{"name": f.tools[page]}}
+		}
+		if page+1 < len(f.tools) {
+			result["nextCursor"] = fmt.Sprintf("page-%d", page+1)
+		}
+		resp["result"] = result
+	default:
+		resp["error"] = map[string]any{"code": -32601, "message": "method not found"}
+	}
+	return resp
+}
+
+func decodeMCPRequest(r *http.Request) (jsonRPCRequest, json.RawMessage, error) {
+	var raw struct {
+		jsonRPCRequest
+		Params json.RawMessage `json:"params"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&raw)
+	return raw.jsonRPCRequest, raw.Params, err
+}
+
+func (f fakeMCPServer) streamableHTTP() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			return
+		}
+		msg, params, err := decodeMCPRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if msg.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != "session-1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Mcp-Session-Id", "session-1")
+		resp := f.handle(msg, params)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		body, _ := json.Marshal(resp)
+		if f.sseResponses {
+			w.Header().Set("Content-Type", "text/event-stream")
+			// Unrelated messages on the stream are skipped.
+			_, _ = fmt.Fprintf(w, ": keepalive\n\nevent: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\ndata: %s\n\n", body)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
+
+// legacySSE serves the legacy transport: responses to POSTs on /messages
+// arrive on the event stream opened with GET /sse.
+func (f fakeMCPServer) legacySSE() http.Handler {
+	var mu sync.Mutex
+	var stream chan []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sse", func(w http.ResponseWriter, r *http.Request) {
+		if !f.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		messages := make(chan []byte, 8)
+		mu.Lock()
+		stream = messages
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: endpoint\ndata: /messages?session=1\n\n")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case body := <-messages:
+				_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", body)
+				w.(http.Flusher).Flush()
+			}
+		}
+	})
+	mux.HandleFunc("POST /messages", func(w http.ResponseWriter, r *http.Request) {
+		msg, params, err := decodeMCPRequest(r)
+		if err != nil || r.URL.Query().Get("session") != "1" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if resp := f.handle(msg, params); resp != nil {
+			body, _ := json.Marshal(resp)
+			mu.Lock()
+			stream <- body
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return mux
+}
+
+func TestListMCPTools(t *testing.T) {
+	t.Parallel()
+
+	tools := []string{"create_issue", "list_issues", "search"}
+
+	t.Run("StreamableHTTP", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{tools: tools}.streamableHTTP())
+		t.Cleanup(srv.Close)
+		got, err := listMCPTools(context.Background(), srv.Client(), "test", "streamable_http", srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, tools, got)
+	})
+
+	t.Run("StreamableHTTPEventStream", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{tools: tools, sseResponses: true}.streamableHTTP())
+		t.Cleanup(srv.Close)
+		got, err := listMCPTools(context.Background(), srv.Client(), "test", "streamable_http", srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, tools, got)
+	})
+
+	t.Run("NoTools", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{}.streamableHTTP())
+		t.Cleanup(srv.Close)
+		got, err := listMCPTools(context.Background(), srv.Client(), "test", "streamable_http", srv.URL, nil)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.Empty(t, got)
+	})
+
+	t.Run("LegacySSE", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{tools: tools}.legacySSE())
+		t.Cleanup(srv.Close)
+		got, err := listMCPTools(context.Background(), srv.Client(), "test", "sse", srv.URL+"/sse", nil)
+		require.NoError(t, err)
+		require.Equal(t, tools, got)
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{tools: tools, apiKey: "secret"}.streamableHTTP())
+		t.Cleanup(srv.Close)
+		headers := http.Header{}
+		headers.Set("X-API-Key", "secret")
+		got, err := listMCPTools(context.Background(), srv.Client(), "test", "streamable_http", srv.URL, headers)
+		require.NoError(t, err)
+		require.Equal(t, tools, got)
+	})
+
+	t.Run("ClientVersion", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(fakeMCPServer{tools: tools, clientVersion: "1.2.3"}.streamableHTTP())
+		t.Cleanup(srv.Close)
+		got, err := listMCPTools(context.Background(), srv.Client(), "1.2.3", "streamable_http", srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, tools, got)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		for _, transport := range []string{"streamable_http", "sse"} {
+			f := fakeMCPServer{tools: tools, apiKey: "secret"}
+			handler, serverPath := f.streamableHTTP(), ""
+			if transport == "sse" {
+				handler, serverPath = f.legacySSE(), "/sse"
+			}
+			srv := httptest.NewServer(handler)
+			t.Cleanup(srv.Close)
+			_, err := listMCPTools(context.Background(), srv.Client(), "test", transport, srv.URL+serverPath, nil)
+			var httpErr *verifyHTTPError
+			require.True(t, errors.As(err, &httpErr), transport)
+			require.True(t, httpErr.Unauthorized(), transport)
+		}
+	})
+
+	t.Run("NotMCP", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("<html></html>"))
+		}))
+		t.Cleanup(srv.Close)
+		_, err := listMCPTools(context.Background(), srv.Client(), "test", "streamable_http", srv.URL, nil)
+		require.ErrorContains(t, err, "initialize")
+	})
+}
+
+func TestReadSSE(t *testing.T) {
+	t.Parallel()
+
+	var events []sseEvent
+	err := readSSE(strings.NewReader(": comment\nevent: endpoint\ndata: /messages\n\ndata: line one\ndata: line two\n\nevent: ignored\n\n"), func(event sseEvent) bool {
+		events = append(events, event)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []sseEvent{
+		{Event: "endpoint", Data: "/messages"},
+		{Event: "message", Data: "line one\nline two"},
+	}, events)
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationTemplateMethodResource{}
+var _ resource.ResourceWithImportState = &NotificationTemplateMethodResource{}
+var _ resource.ResourceWithModifyPlan = &NotificationTemplateMethodResource{}
+var _ resourceWithEntitlements = &NotificationTemplateMethodResource{}
+
+type NotificationTemplateMethodResource struct {
+	*CoderdProviderData
+}
+
+// NotificationTemplateMethodResourceModel describes the resource data model.
+type NotificationTemplateMethodResourceModel struct {
+	TemplateID UUID         `tfsdk:"template_id"`
+	Method     types.String `tfsdk:"method"`
+	Name       types.String `tfsdk:"name"`
+	Group      types.String `tfsdk:"group"`
+}
+
+func NewNotificationTemplateMethodResource() resource.Resource {
+	return &NotificationTemplateMethodResource{}
+}
+
+func (r *NotificationTemplateMethodResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_template_method"
+}
+
+func (r *NotificationTemplateMethodResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The dispatch method of a system notification template on the Coder deployment.
+Multiple instances of this resource for a single notification template will conflict.
+
+~> **Warning**
+` + "`terraform destroy`" + ` resets the template to the deployment's default dispatch method (` + "`CODER_NOTIFICATIONS_METHOD`" + `).
+
+~> **Warning**
+This resource requires an Enterprise or Premium license.
+`,
+		Attributes: map[string]schema.Attribute{
+			"template_id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				Required:            true,
+				MarkdownDescription: "The ID of the system notification template.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The method notifications from this template are dispatched with. Valid methods are `smtp`, `webhook`, and `inbox`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(codersdk.NotificationMethodSMTP),
+						string(codersdk.NotificationMethodWebhook),
+						string(codersdk.NotificationMethodInbox),
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the notification template.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The group the notification template belongs to, such as `Workspace Events`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NotificationTemplateMethodResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+// Entitlements implements resourceWithEntitlements. Coder serves the
+// template method endpoint behind template access control.
+func (r *NotificationTemplateMethodResource) Entitlements() []entitlement {
+	return []entitlement{{
+		Feature: codersdk.FeatureTemplateRBAC,
+		Usage:   "use template access control, so you cannot customize notification template methods",
+	}}
+}
+
+func (r *NotificationTemplateMethodResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+}
+
+func (r *NotificationTemplateMethodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data NotificationTemplateMethodResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := data.TemplateID.ValueUUID()
+	tmpl, found, err := r.systemNotificationTemplate(ctx, templateID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list notification templates, got error: %s", err))
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Notification template with ID %q not found. Marking resource as deleted.", templateID.String()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Method = types.StringValue(tmpl.Method)
+	data.Name = types.StringValue(tmpl.Name)
+	data.Group = types.StringValue(tmpl.Group)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationTemplateMethodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data NotificationTemplateMethodResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(r.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := data.TemplateID.ValueUUID()
+	tmpl, found, err := r.systemNotificationTemplate(ctx, templateID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list notification templates, got error: %s", err))
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Notification template with ID %q not found", templateID.String()))
+		return
+	}
+
+	tflog.Trace(ctx, "setting notification template method", map[string]any{
+		"template_id": templateID,
+		"method":      data.Method.ValueString(),
+	})
+	err = r.Client.UpdateNotificationTemplateMethod(ctx, templateID, data.Method.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set notification template method, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "successfully set notification template method")
+
+	data.Name = types.StringValue(tmpl.Name)
+	data.Group = types.StringValue(tmpl.Group)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationTemplateMethodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data NotificationTemplateMethodResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(r.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := data.TemplateID.ValueUUID()
+	tflog.Trace(ctx, "updating notification template method", map[string]any{
+		"template_id": templateID,
+		"method":      data.Method.ValueString(),
+	})
+	err := r.Client.UpdateNotificationTemplateMethod(ctx, templateID, data.Method.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update notification template method, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "successfully updated notification template method")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationTemplateMethodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data NotificationTemplateMethodResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := data.TemplateID.ValueUUID()
+	tflog.Trace(ctx, "resetting notification template method", map[string]any{
+		"template_id": templateID,
+	})
+
+	// An empty method makes the template fall back to the deployment default.
+	err := r.Client.UpdateNotificationTemplateMethod(ctx, templateID, "")
+	if err != nil {
+		if isNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset notification template method, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "successfully reset notification template method")
+}
+
+func (r *NotificationTemplateMethodResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using notification template ID
+	resource.ImportStatePassthroughID(ctx, path.Root("template_id"), req, resp)
+}
+
+// systemNotificationTemplate looks up a system notification template by ID.
+// The API has no single-template endpoint, so this lists them all.
+func (r *NotificationTemplateMethodResource) systemNotificationTemplate(ctx context.Context, id uuid.UUID) (codersdk.NotificationTemplate, bool, error) {
+	templates, err := r.Client.GetSystemNotificationTemplates(ctx)
+	if err != nil {
+		return codersdk.NotificationTemplate{}, false, err
+	}
+	for _, tmpl := range templates {
+		if tmpl.ID == id {
+			return tmpl, true, nil
+		}
+	}
+	return codersdk.NotificationTemplate{}, false, nil
+}
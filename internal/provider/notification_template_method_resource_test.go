@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccNotificationTemplateMethodResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "notification_template_method_acc", integration.UseLicense)
+
+	templates, err := client.GetSystemNotificationTemplates(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+	tmpl := templates[0]
+
+	cfg := func(method string) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_notification_template_method" "test" {
+	template_id = %q
+	method      = %q
+}
+`, client.URL.String(), client.SessionToken(), tmpl.ID.String(), method)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg("webhook"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_notification_template_method.test", "method", "webhook"),
+					resource.TestCheckResourceAttr("coderd_notification_template_method.test", "name", tmpl.Name),
+					resource.TestCheckResourceAttr("coderd_notification_template_method.test", "group", tmpl.Group),
+				),
+			},
+			{
+				Config:                               cfg("webhook"),
+				ResourceName:                         "coderd_notification_template_method.test",
+				ImportState:                          true,
+				ImportStateId:                        tmpl.ID.String(),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "template_id",
+			},
+			{
+				Config: cfg("smtp"),
+				Check:  resource.TestCheckResourceAttr("coderd_notification_template_method.test", "method", "smtp"),
+			},
+		},
+	})
+
+	// Destroy restores the deployment default.
+	templates, err = client.GetSystemNotificationTemplates(ctx)
+	require.NoError(t, err)
+	for _, got := range templates {
+		if got.ID == tmpl.ID {
+			require.Empty(t, got.Method)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationsSettingsResource{}
+var _ resource.ResourceWithImportState = &NotificationsSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &NotificationsSettingsResource{}
+
+// notificationsSettingsDefaultPaused is the deployment default for
+// `notifier_paused`, restored by Delete since the API has no DELETE verb.
+const notificationsSettingsDefaultPaused = false
+
+var pathNotifierPaused = path.Root("notifier_paused")
+
+type NotificationsSettingsResource struct {
+	*CoderdProviderData
+}
+
+// NotificationsSettingsResourceModel describes the resource data model.
+type NotificationsSettingsResourceModel struct {
+	NotifierPaused types.Bool `tfsdk:"notifier_paused"`
+	AllowOverwrite types.Bool `tfsdk:"allow_overwrite"`
+}
+
+func NewNotificationsSettingsResource() resource.Resource {
+	return &NotificationsSettingsResource{}
+}
+
+func (r *NotificationsSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notifications_settings"
+}
+
+func (r *NotificationsSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Deployment-wide notification settings.
+
+Declare this resource at most once. Pausing or resuming the notifier outside this configuration makes the next plan fail, unless ` + "`allow_overwrite`" + ` is set.
+
+~> **Warning**
+If the notifier was paused out of band, ` + "`terraform import`" + ` this resource before the first apply. Otherwise Terraform overwrites the live value; a plan-time warning is emitted when this is about to happen.
+
+~> **Warning**
+` + "`terraform destroy`" + ` resets ` + "`notifier_paused`" + ` to ` + "`false`" + `, the deployment default. The API has no delete operation for this setting.
+
+~> **Warning**
+This resource requires a token with site-wide ` + "`owner`" + ` permissions.
+`,
+		Attributes: map[string]schema.Attribute{
+			"allow_overwrite": allowOverwriteSchema("the notifier state"),
+			"notifier_paused": schema.BoolAttribute{
+				Required: true,
+				MarkdownDescription: "Whether notification dispatch is paused. While paused, notifications are still enqueued " +
+					"but none are sent until the notifier is resumed.",
+			},
+		},
+	}
+}
+
+func (r *NotificationsSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *NotificationsSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data NotificationsSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.Client.GetNotificationsSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read notifications settings, got error: %s", err))
+		return
+	}
+
+	data.NotifierPaused = types.BoolValue(settings.NotifierPaused)
+	resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private, settings.NotifierPaused)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationsSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data NotificationsSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating notifications settings", map[string]any{
+		"notifier_paused": data.NotifierPaused.ValueBool(),
+	})
+
+	paused, diags := r.put(ctx, "create", data.NotifierPaused.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.NotifierPaused = types.BoolValue(paused)
+
+	tflog.Trace(ctx, "successfully created notifications settings")
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, paused)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationsSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data NotificationsSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating notifications settings", map[string]any{
+		"notifier_paused": data.NotifierPaused.ValueBool(),
+	})
+
+	paused, diags := r.put(ctx, "update", data.NotifierPaused.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.NotifierPaused = types.BoolValue(paused)
+
+	tflog.Trace(ctx, "successfully updated notifications settings")
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, paused)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationsSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleting notifications settings")
+
+	// There is no DELETE endpoint for this setting, so reset it to the
+	// deployment default instead.
+	if _, diags := r.put(ctx, "reset", notificationsSettingsDefaultPaused); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	tflog.Trace(ctx, "successfully deleted notifications settings")
+}
+
+// put writes paused and returns the value the deployment reports afterwards.
+// action names the Terraform operation for error messages.
+func (r *NotificationsSettingsResource) put(ctx context.Context, action string, paused bool) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	updated, err := r.Client.PutNotificationsSettings(ctx, codersdk.NotificationsSettings{
+		NotifierPaused: paused,
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s notifications settings, got error: %s", action, err))
+		return paused, diags
+	}
+	return updated.NotifierPaused, diags
+}
+
+// ModifyPlan warns when a first apply is about to resume a notifier that is
+// currently paused. Pausing is never flagged: a live `false` is the default,
+// so it cannot be told apart from "never configured". It also runs the
+// singleton conflict checks; see singleton.go.
+func (r *NotificationsSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to advise on.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.claimSingleton("coderd_notifications_settings")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_notifications_settings", func() (any, error) {
+			settings, err := r.Client.GetNotificationsSettings(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return settings.NotifierPaused, nil
+		})...)
+		return
+	}
+
+	var data NotificationsSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.NotifierPaused.IsUnknown() || data.NotifierPaused.IsNull() || data.NotifierPaused.ValueBool() {
+		return
+	}
+
+	settings, err := r.Client.GetNotificationsSettings(ctx)
+	if err != nil {
+		// Best-effort advisory only; Create reports the same failure.
+		tflog.Debug(ctx, "skipping notifications settings plan-time check", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	if !settings.NotifierPaused {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		pathNotifierPaused,
+		"Overwriting an out-of-band value",
+		"The notifier is currently paused on this deployment, and applying will resume it. Terraform has no prior "+
+			"state for this resource, so this change is not shown as a diff.\n\n"+
+			"If you meant to adopt the deployment's existing value rather than overwrite it, run "+
+			"`terraform import coderd_notifications_settings.<name> notifications_settings` first.",
+	)
+}
+
+func (r *NotificationsSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The singleton has no ID, but Read needs a non-null placeholder state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, NotificationsSettingsResourceModel{
+		NotifierPaused: types.BoolValue(notificationsSettingsDefaultPaused),
+	})...)
+}
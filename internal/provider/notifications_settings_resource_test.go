@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccNotificationsSettingsResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "notifications_settings_acc")
+
+	cfg := func(paused bool) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_notifications_settings" "test" {
+	notifier_paused = %t
+}
+`, client.URL.String(), client.SessionToken(), paused)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(true),
+				Check:  resource.TestCheckResourceAttr("coderd_notifications_settings.test", "notifier_paused", "true"),
+			},
+			{
+				Config:                               cfg(true),
+				ResourceName:                         "coderd_notifications_settings.test",
+				ImportState:                          true,
+				ImportStateId:                        "notifications_settings",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "notifier_paused",
+			},
+			{
+				Config: cfg(false),
+				Check:  resource.TestCheckResourceAttr("coderd_notifications_settings.test", "notifier_paused", "false"),
+			},
+			{
+				Config: cfg(true),
+				Check:  resource.TestCheckResourceAttr("coderd_notifications_settings.test", "notifier_paused", "true"),
+			},
+		},
+	})
+
+	// Destroy resumes the notifier.
+	settings, err := client.GetNotificationsSettings(ctx)
+	require.NoError(t, err)
+	require.False(t, settings.NotifierPaused)
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OAuth2AppResource{}
+var _ resource.ResourceWithImportState = &OAuth2AppResource{}
+
+// oauth2AppExperimentWarning is shared by the OAuth2 app resources, which sit
+// behind the same experiment gate as coderd_oauth2_provider_settings.
+var oauth2AppExperimentWarning = `~> **Warning**
+Requires the ` + "`" + oauth2ProviderSettingsExperiment + "`" + ` experiment (` + "`CODER_EXPERIMENTS=" + oauth2ProviderSettingsExperiment + "`" + ` or ` + "`--experiments=" + oauth2ProviderSettingsExperiment + "`" + `); ` + "`*`" + ` does not enable it. Without it, every request to ` + "`/api/v2/oauth2-provider`" + ` fails with ` + "`403`" + `.
+`
+
+// oauth2AppDefaultGrantTypes are the grant types Coder gives an app registered
+// without any.
+var oauth2AppDefaultGrantTypes = []codersdk.OAuth2ProviderGrantType{
+	codersdk.OAuth2ProviderGrantTypeAuthorizationCode,
+	codersdk.OAuth2ProviderGrantTypeRefreshToken,
+}
+
+func NewOAuth2AppResource() resource.Resource {
+	return &OAuth2AppResource{}
+}
+
+// OAuth2AppResource defines the resource implementation.
+type OAuth2AppResource struct {
+	*CoderdProviderData
+}
+
+// OAuth2AppResourceModel describes the resource data model.
+type OAuth2AppResourceModel struct {
+	ID           UUID         `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	CallbackURLs types.List   `tfsdk:"callback_urls"`
+	Icon         types.String `tfsdk:"icon"`
+	GrantTypes   types.Set    `tfsdk:"grant_types"`
+}
+
+func (r *OAuth2AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_app"
+}
+
+func (r *OAuth2AppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	grantTypes := make([]string, 0, 4)
+	for _, grantType := range []codersdk.OAuth2ProviderGrantType{
+		codersdk.OAuth2ProviderGrantTypeAuthorizationCode,
+		codersdk.OAuth2ProviderGrantTypeRefreshToken,
+		codersdk.OAuth2ProviderGrantTypeClientCredentials,
+		codersdk.OAuth2ProviderGrantTypeDeviceCode,
+	} {
+		grantTypes = append(grantTypes, string(grantType))
+	}
+	defaultGrantTypes := make([]attr.Value, 0, len(oauth2AppDefaultGrantTypes))
+	for _, grantType := range oauth2AppDefaultGrantTypes {
+		defaultGrantTypes = append(defaultGrantTypes, types.StringValue(string(grantType)))
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `An OAuth2 application that uses the Coder deployment as its identity provider.
+
+Use ` + "`coderd_oauth2_app_secret`" + ` to issue the app's client secrets.
+
+` + oauth2AppExperimentWarning,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				MarkdownDescription: "The ID of the app, which is also its OAuth2 client ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the app, shown to users when they authorize it. Must be unique.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"callback_urls": schema.ListAttribute{
+				MarkdownDescription: "The URLs Coder may redirect users to after they authorize the app. The first is the app's default callback URL.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"icon": schema.StringAttribute{
+				MarkdownDescription: "Relative path or external URL that specifies an icon to be displayed in the dashboard.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"grant_types": schema.SetAttribute{
+				MarkdownDescription: "The OAuth2 grant types the app may use. Defaults to `authorization_code` and `refresh_token`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, defaultGrantTypes)),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(grantTypes...)),
+				},
+			},
+		},
+	}
+}
+
+func (r *OAuth2AppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *OAuth2AppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data OAuth2AppResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var callbackURLs, grantTypes []string
+	resp.Diagnostics.Append(data.CallbackURLs.ElementsAs(ctx, &callbackURLs, false)...)
+	resp.Diagnostics.Append(data.GrantTypes.ElementsAs(ctx, &grantTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "creating oauth2 app", map[string]any{"name": data.Name.ValueString()})
+	app, err := r.Client.PostOAuth2ProviderApp(ctx, codersdk.PostOAuth2ProviderAppRequest{
+		Name:         data.Name.ValueString(),
+		CallbackURL:  callbackURLs[0],
+		RedirectURIs: callbackURLs,
+		Icon:         data.Icon.ValueString(),
+		GrantTypes:   oauth2GrantTypes(grantTypes),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create OAuth2 app, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, "successfully created oauth2 app", map[string]any{"id": app.ID.String()})
+
+	resp.Diagnostics.Append(data.setApp(app)...)
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OAuth2AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data OAuth2AppResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.Client.OAuth2ProviderApp(ctx, data.ID.ValueUUID())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("OAuth2 app with ID %s not found. Marking as deleted.", data.ID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get OAuth2 app, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.setApp(app)...)
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OAuth2AppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data OAuth2AppResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var callbackURLs, grantTypes []string
+	resp.Diagnostics.Append(data.CallbackURLs.ElementsAs(ctx, &callbackURLs, false)...)
+	resp.Diagnostics.Append(data.GrantTypes.ElementsAs(ctx, &grantTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "updating oauth2 app", map[string]any{"id": data.ID.ValueString()})
+	app, err := r.Client.PutOAuth2ProviderApp(ctx, data.ID.ValueUUID(), codersdk.PutOAuth2ProviderAppRequest{
+		Name:         data.Name.ValueString(),
+		CallbackURL:  callbackURLs[0],
+		RedirectURIs: callbackURLs,
+		Icon:         data.Icon.ValueString(),
+		GrantTypes:   oauth2GrantTypes(grantTypes),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update OAuth2 app, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, "successfully updated oauth2 app")
+
+	resp.Diagnostics.Append(data.setApp(app)...)
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OAuth2AppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data OAuth2AppResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "deleting oauth2 app", map[string]any{"id": data.ID.ValueString()})
+	// Deleting the app also deletes its secrets.
+	err := r.Client.DeleteOAuth2ProviderApp(ctx, data.ID.ValueUUID())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete OAuth2 app, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, "successfully deleted oauth2 app")
+}
+
+func (r *OAuth2AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	appID, err := uuid.Parse(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid import ID format, expected a single UUID. Got: %s", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), UUIDValue(appID))...)
+}
+
+// setApp fills in the model from the app the deployment reports.
+func (m *OAuth2AppResourceModel) setApp(app codersdk.OAuth2ProviderApp) (diags diag.Diagnostics) {
+	m.ID = UUIDValue(app.ID)
+	m.Name = types.StringValue(app.Name)
+	m.Icon = types.StringValue(app.Icon)
+
+	// Apps registered before multiple callback URLs were supported only
+	// report the one.
+	callbackURLs := app.RedirectURIs
+	if len(callbackURLs) == 0 {
+		callbackURLs = []string{app.CallbackURL}
+	}
+	callbackURLValues := make([]attr.Value, 0, len(callbackURLs))
+	for _, callbackURL := range callbackURLs {
+		callbackURLValues = append(callbackURLValues, types.StringValue(callbackURL))
+	}
+	m.CallbackURLs = types.ListValueMust(types.StringType, callbackURLValues)
+
+	grantTypes := app.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = oauth2AppDefaultGrantTypes
+	}
+	grantTypeValues := make([]attr.Value, 0, len(grantTypes))
+	for _, grantType := range grantTypes {
+		grantTypeValues = append(grantTypeValues, types.StringValue(string(grantType)))
+	}
+	m.GrantTypes, diags = types.SetValue(types.StringType, grantTypeValues)
+	return diags
+}
+
+func oauth2GrantTypes(grantTypes []string) []codersdk.OAuth2ProviderGrantType {
+	out := make([]codersdk.OAuth2ProviderGrantType, 0, len(grantTypes))
+	for _, grantType := range grantTypes {
+		out = append(out, codersdk.OAuth2ProviderGrantType(grantType))
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOAuth2AppResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "oauth2_app_acc", integration.CoderExperiments(oauth2ProviderSettingsExperiment))
+
+	cfg1 := testAccOAuth2AppResourceConfig{
+		URL:          client.URL.String(),
+		Token:        client.SessionToken(),
+		Name:         "example-app",
+		CallbackURLs: []string{"https://example.com/callback"},
+	}
+
+	cfg2 := cfg1
+	cfg2.Name = "example-app-new"
+	cfg2.CallbackURLs = []string{"https://example.com/callback", "http://localhost:8080/callback"}
+	cfg2.Icon = "/emojis/1f310.png"
+	cfg2.GrantTypes = []string{"authorization_code"}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg1.String(t),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("name"), knownvalue.StringExact("example-app")),
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("icon"), knownvalue.StringExact("")),
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("grant_types"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("authorization_code"),
+						knownvalue.StringExact("refresh_token"),
+					})),
+				},
+			},
+			// Import testing
+			{
+				ResourceName:      "coderd_oauth2_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: cfg2.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_oauth2_app.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("callback_urls"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("https://example.com/callback"),
+						knownvalue.StringExact("http://localhost:8080/callback"),
+					})),
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("icon"), knownvalue.StringExact("/emojis/1f310.png")),
+					statecheck.ExpectKnownValue("coderd_oauth2_app.test", tfjsonpath.New("grant_types"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("authorization_code"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+type testAccOAuth2AppResourceConfig struct {
+	URL   string
+	Token string
+
+	Name         string
+	CallbackURLs []string
+	Icon         string
+	GrantTypes   []string
+}
+
+func (c testAccOAuth2AppResourceConfig) String(t *testing.T) string {
+	t.Helper()
+	tpl := `
+provider coderd {
+	url   = "{{.URL}}"
+	token = "{{.Token}}"
+}
+
+resource "coderd_oauth2_app" "test" {
+	name          = "{{.Name}}"
+	callback_urls = [{{range .CallbackURLs}}"{{.}}", {{end}}]
+{{- if .Icon}}
+	icon          = "{{.Icon}}"
+{{- end}}
+{{- if .GrantTypes}}
+	grant_types   = [{{range .GrantTypes}}"{{.}}", {{end}}]
+{{- end}}
+}
+`
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("oauth2AppResource").Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, c)
+	require.NoError(t, err)
+	return buf.String()
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OAuth2AppSecretResource{}
+
+func NewOAuth2AppSecretResource() resource.Resource {
+	return &OAuth2AppSecretResource{}
+}
+
+// OAuth2AppSecretResource defines the resource implementation.
+type OAuth2AppSecretResource struct {
+	*CoderdProviderData
+}
+
+// OAuth2AppSecretResourceModel describes the resource data model.
+type OAuth2AppSecretResourceModel struct {
+	ID                    UUID         `tfsdk:"id"`
+	AppID                 UUID         `tfsdk:"app_id"`
+	RotationTrigger       types.String `tfsdk:"rotation_trigger"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretTruncated types.String `tfsdk:"client_secret_truncated"`
+}
+
+func (r *OAuth2AppSecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_app_secret"
+}
+
+func (r *OAuth2AppSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `A client secret for a ` + "`coderd_oauth2_app`" + `.
+
+Coder only reveals a secret when it's created, so secrets can't be imported. To rotate a secret, change ` + "`rotation_trigger`" + `. With ` + "`create_before_destroy`" + `, the new secret is issued before the old one is revoked, and an app can hold several secrets while its clients switch over.
+
+` + oauth2AppExperimentWarning,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				MarkdownDescription: "The ID of the secret.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				MarkdownDescription: "The ID of the app the secret belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value that replaces the secret whenever it changes, such as the ID of a `time_rotating` resource.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The client secret. Only known to Terraform for secrets it created.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret_truncated": schema.StringAttribute{
+				MarkdownDescription: "The first few characters of the client secret, as shown in the dashboard. Null until the next refresh if it couldn't be read when the secret was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *OAuth2AppSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *OAuth2AppSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data OAuth2AppSecretResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "creating oauth2 app secret", map[string]any{"app_id": data.AppID.ValueString()})
+	secret, err := r.Client.PostOAuth2ProviderAppSecret(ctx, data.AppID.ValueUUID())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create OAuth2 app secret, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, "successfully created oauth2 app secret", map[string]any{"id": secret.ID.String()})
+
+	data.ID = UUIDValue(secret.ID)
+	data.ClientSecret = types.StringValue(secret.ClientSecretFull)
+	data.ClientSecretTruncated = types.StringNull()
+
+	// The full secret is only revealed now, so save it before anything else
+	// can fail.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The creation response only carries the full secret.
+	secrets, err := r.Client.OAuth2ProviderAppSecrets(ctx, data.AppID.ValueUUID())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Unable to list OAuth2 app secrets, got error: %s. `client_secret_truncated` will be set on the next refresh.", err))
+		return
+	}
+	data.ClientSecretTruncated = types.StringValue("")
+	for _, s := range secrets {
+		if s.ID == secret.ID {
+			data.ClientSecretTruncated = types.StringValue(s.ClientSecretTruncated)
+			break
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OAuth2AppSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data OAuth2AppSecretResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets, err := r.Client.OAuth2ProviderAppSecrets(ctx, data.AppID.ValueUUID())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("OAuth2 app with ID %s not found. Marking secret as deleted.", data.AppID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list OAuth2 app secrets, got error: %s", err))
+		return
+	}
+
+	found := false
+	for _, s := range secrets {
+		if s.ID == data.ID.ValueUUID() {
+			data.ClientSecretTruncated = types.StringValue(s.ClientSecretTruncated)
+			found = true
+			break
+		}
+	}
+	if !found {
+		resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("OAuth2 app secret with ID %s not found. Marking as deleted.", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OAuth2AppSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement.
+	resp.Diagnostics.AddError("Invalid Update", "Terraform is attempting to update a resource which must be replaced")
+}
+
+func (r *OAuth2AppSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data OAuth2AppSecretResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "deleting oauth2 app secret", map[string]any{"id": data.ID.ValueString()})
+	err := r.Client.DeleteOAuth2ProviderAppSecret(ctx, data.AppID.ValueUUID(), data.ID.ValueUUID())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete OAuth2 app secret, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, "successfully deleted oauth2 app secret")
+}
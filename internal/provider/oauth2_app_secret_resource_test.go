@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOAuth2AppSecretResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "oauth2_app_secret_acc", integration.CoderExperiments(oauth2ProviderSettingsExperiment))
+
+	cfg1 := testAccOAuth2AppSecretResourceConfig{
+		URL:   client.URL.String(),
+		Token: client.SessionToken(),
+	}
+
+	cfg2 := cfg1
+	cfg2.RotationTrigger = "2026-10"
+
+	var secretID string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg1.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderd_oauth2_app_secret.test", "client_secret"),
+					resource.TestCheckResourceAttrSet("coderd_oauth2_app_secret.test", "client_secret_truncated"),
+					resource.TestCheckResourceAttrWith("coderd_oauth2_app_secret.test", "id", func(value string) error {
+						secretID = value
+						return nil
+					}),
+				),
+			},
+			// Rotation testing
+			{
+				Config: cfg2.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_oauth2_app_secret.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					secrets, err := client.OAuth2ProviderAppSecrets(ctx, uuid.MustParse(s.RootModule().Resources["coderd_oauth2_app.test"].Primary.ID))
+					if err != nil {
+						return err
+					}
+					// The old secret is revoked once the new one is issued.
+					if len(secrets) != 1 || secrets[0].ID.String() == secretID {
+						return fmt.Errorf("expected only the rotated secret, got %d secrets", len(secrets))
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+type testAccOAuth2AppSecretResourceConfig struct {
+	URL   string
+	Token string
+
+	RotationTrigger string
+}
+
+func (c testAccOAuth2AppSecretResourceConfig) String(t *testing.T) string {
+	t.Helper()
+	tpl := `
+provider coderd {
+	url   = "{{.URL}}"
+	token = "{{.Token}}"
+}
+
+resource "coderd_oauth2_app" "test" {
+	name          = "example-app"
+	callback_urls = ["https://example.com/callback"]
+}
+
+resource "coderd_oauth2_app_secret" "test" {
+	app_id           = coderd_oauth2_app.test.id
+{{- if .RotationTrigger}}
+	rotation_trigger = "{{.RotationTrigger}}"
+{{- end}}
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("oauth2AppSecretResource").Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, c)
+	require.NoError(t, err)
+	return buf.String()
+}
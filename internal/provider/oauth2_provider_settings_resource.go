@@ -63,6 +63,7 @@ type OAuth2ProviderSettingsResource struct {
 // OAuth2ProviderSettingsResourceModel describes the resource data model.
 type OAuth2ProviderSettingsResourceModel struct {
 	DynamicClientRegistrationEnabled types.Bool `tfsdk:"dynamic_client_registration_enabled"`
+	AllowOverwrite                   types.Bool `tfsdk:"allow_overwrite"`
 }
 
 func NewOAuth2ProviderSettingsResource() resource.Resource {
@@ -77,7 +78,7 @@ func (r *OAuth2ProviderSettingsResource) Schema(ctx context.Context, req resourc
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Deployment-wide OAuth2 provider settings.
 
-This is a deployment-wide singleton. Declare it once; duplicate resources silently overwrite each other.
+Declare this resource at most once. If dynamic client registration is toggled outside this configuration, the next plan fails unless ` + "`allow_overwrite`" + ` is set.
 
 ~> **Warning**
 If DCR was configured out of band, ` + "`terraform import`" + ` this resource before the first apply. Otherwise Terraform overwrites the live value without a diff; disabling an enabled setting emits a non-blocking warning.
@@ -96,6 +97,7 @@ This resource is only compatible with Coder version [` + oauth2ProviderSettingsM
 Requires the ` + "`" + oauth2ProviderSettingsExperiment + "`" + ` experiment (` + "`CODER_EXPERIMENTS=" + oauth2ProviderSettingsExperiment + "`" + ` or ` + "`--experiments=" + oauth2ProviderSettingsExperiment + "`" + `); ` + "`*`" + ` does not enable it. Without it, ` + "`/api/v2/oauth2-provider/settings`" + ` returns ` + "`403`" + ` (development builds bypass this check).
 `,
 		Attributes: map[string]schema.Attribute{
+			"allow_overwrite": allowOverwriteSchema("dynamic client registration"),
 			"dynamic_client_registration_enabled": schema.BoolAttribute{
 				Required: true,
 				MarkdownDescription: "Whether OAuth2 Dynamic Client Registration ([RFC 7591](https://datatracker.ietf.org/doc/html/rfc7591)) " +
@@ -144,6 +146,7 @@ func (r *OAuth2ProviderSettingsResource) Read(ctx context.Context, req resource.
 	}
 
 	data.DynamicClientRegistrationEnabled = types.BoolValue(dcrEnabledOrDefault(settings))
+	resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private, dcrEnabledOrDefault(settings))...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -173,6 +176,7 @@ func (r *OAuth2ProviderSettingsResource) Create(ctx context.Context, req resourc
 	tflog.Trace(ctx, "successfully created oauth2 provider settings", map[string]any{
 		"dynamic_client_registration_enabled": effective,
 	})
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, effective)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -201,6 +205,7 @@ func (r *OAuth2ProviderSettingsResource) Update(ctx context.Context, req resourc
 	tflog.Trace(ctx, "successfully updated oauth2 provider settings", map[string]any{
 		"dynamic_client_registration_enabled": effective,
 	})
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private, effective)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -269,6 +274,7 @@ func (r *OAuth2ProviderSettingsResource) Delete(ctx context.Context, req resourc
 
 // ModifyPlan warns, at plan time, when a first apply is about to disable
 // Dynamic Client Registration on a deployment where it is currently enabled.
+// It also runs the singleton conflict checks; see singleton.go.
 //
 // That is the one direction worth flagging. A create has no prior state to
 // diff against, so Terraform renders it as a plain "will be created" with no
@@ -285,14 +291,26 @@ func (r *OAuth2ProviderSettingsResource) ModifyPlan(ctx context.Context, req res
 	if req.Plan.Raw.IsNull() {
 		return
 	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.claimSingleton("coderd_oauth2_provider_settings")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Only a genuine create reaches the no-prior-state case this warns about.
 	// `terraform import` populates state without ever running Create(), so
-	// this correctly stays quiet on the first plan after an import.
+	// this correctly stays quiet on the first plan after an import. Existing
+	// resources are instead checked against the value they last applied.
 	if !req.State.Raw.IsNull() {
-		return
-	}
-	// Configure() has not run during the validate walk.
-	if r.CoderdProviderData == nil {
+		resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_oauth2_provider_settings", func() (any, error) {
+			settings, err := r.Client.OAuth2ProviderSettings(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return dcrEnabledOrDefault(settings), nil
+		})...)
 		return
 	}
 
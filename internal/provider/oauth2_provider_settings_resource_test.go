@@ -432,11 +432,12 @@ resource "coderd_oauth2_provider_settings" "test" {
 					Config: oauth2SettingsConfig(f.URL, true),
 				},
 				{
-					// Another actor flips the value via the CLI or API.
-					PreConfig:          func() { f.SetDCREnabled(false) },
-					Config:             oauth2SettingsConfig(f.URL, true),
-					PlanOnly:           true,
-					ExpectNonEmptyPlan: true,
+					// Another actor flips the value via the CLI or API. The
+					// plan fails rather than silently overwriting it.
+					PreConfig:   func() { f.SetDCREnabled(false) },
+					Config:      oauth2SettingsConfig(f.URL, true),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`Singleton Changed Elsewhere`),
 				},
 			},
 		})
@@ -691,9 +692,9 @@ resource "coderd_oauth2_provider_settings" "test" {
 		})
 	})
 
-	// TC15 — Two resource blocks targeting the same singleton. This is not an
-	// error: the API has no "already exists" concept, so the blocks silently
-	// fight, leaving a permanent diff.
+	// TC15 — Two resource blocks targeting the same singleton. The API has no
+	// "already exists" concept, so the blocks would silently fight, leaving a
+	// permanent diff. The plan is refused before either writes.
 	t.Run("TC15_TwoBlocksSameSingleton", func(t *testing.T) {
 		f := newFakeCoderd(t)
 
@@ -713,19 +714,13 @@ resource "coderd_oauth2_provider_settings" "b" {
 			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 			Steps: []resource.TestStep{
 				{
-					Config: cfg,
-					// Whichever block applied second overwrote the other, so
-					// the loser refreshes to a value that disagrees with its
-					// config, forever.
-					ExpectNonEmptyPlan: true,
-					Check: func(*terraform.State) error {
-						assert.Equal(t, 2, f.SettingsRequestCount(http.MethodPut),
-							"both blocks should have written, with no collision error")
-						return nil
-					},
+					Config:      cfg,
+					ExpectError: regexp.MustCompile(`Duplicate Singleton Resource`),
 				},
 			},
 		})
+
+		assert.Zero(t, f.SettingsRequestCount(http.MethodPut), "a refused plan must not write")
 	})
 
 	// State is built from the value the PUT reports back, not from the plan.
@@ -873,6 +868,45 @@ resource "coderd_oauth2_provider_settings" "b" {
 			},
 		})
 	})
+
+	// A write after this configuration applied the setting fails the next
+	// plan, since another configuration may manage it. Once
+	// `allow_overwrite` is set, the plan only warns, and applying restores the
+	// configured value.
+	t.Run("ForeignWriteFailsUnlessAllowed", func(t *testing.T) {
+		f := newFakeCoderd(t)
+		allowOverwrite := oauth2SettingsProviderBlock(f.URL) + `
+resource "coderd_oauth2_provider_settings" "test" {
+	dynamic_client_registration_enabled = true
+	allow_overwrite                     = true
+}
+`
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: oauth2SettingsConfig(f.URL, true),
+				},
+				{
+					PreConfig:   func() { f.SetDCREnabled(false) },
+					Config:      oauth2SettingsConfig(f.URL, true),
+					ExpectError: regexp.MustCompile(`Singleton Changed Elsewhere`),
+				},
+				{
+					Config: allowOverwrite,
+					Check: func(*terraform.State) error {
+						if !f.DCREnabled() {
+							return fmt.Errorf("expected the foreign write to be overwritten")
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
 }
 
 // TestAccOAuth2ProviderSettingsNotDeclared covers the proposal's 5.1 group:
@@ -24,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationGroupSyncResource{}
 var _ resource.ResourceWithImportState = &OrganizationGroupSyncResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationGroupSyncResource{}
 
 type OrganizationGroupSyncResource struct {
 	*CoderdProviderData
@@ -49,7 +50,7 @@ func (r *OrganizationGroupSyncResource) Metadata(ctx context.Context, req resour
 func (r *OrganizationGroupSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Group sync settings for an organization on the Coder deployment. 
-Multiple instances of this resource for a single organization will conflict.
+Multiple instances of this resource for a single organization, or this resource together with the ` + "`group_sync`" + ` block of ` + "`coderd_organization`" + `, will conflict, and planning them fails with an error.
 
 ~> **Warning**
 This resource is only compatible with Coder version [2.16.0](https://github.com/coder/coder/releases/tag/v2.16.0) and later.
@@ -88,7 +89,7 @@ This resource is only compatible with Coder version [2.16.0](https://github.com/
 			"mapping": schema.MapAttribute{
 				ElementType:         types.ListType{ElemType: UUIDType},
 				Required:            true,
-				MarkdownDescription: "A map from OIDC group name to Coder group ID.",
+				MarkdownDescription: "A map from OIDC group name to Coder group ID. Groups are checked against the organization at plan time, unless their IDs are not yet known.",
 			},
 		},
 	}
@@ -114,6 +115,29 @@ func (r *OrganizationGroupSyncResource) Configure(ctx context.Context, req resou
 	r.CoderdProviderData = data
 }
 
+// ModifyPlan checks that no other resource manages the organization's group
+// sync, and that every mapped group exists in the organization.
+func (r *OrganizationGroupSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to check.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+
+	var data OrganizationGroupSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.OrganizationID.IsUnknown() {
+		return
+	}
+	orgID := data.OrganizationID.ValueUUID()
+
+	resp.Diagnostics.Append(r.claimOrganizationSetting("group sync", orgID, "`coderd_organization_group_sync`")...)
+	resp.Diagnostics.Append(r.validateGroupSyncMapping(ctx, path.Root("mapping"), orgID, data.Mapping)...)
+}
+
 func (r *OrganizationGroupSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Read Terraform prior state data into the model
 	var data OrganizationGroupSyncResourceModel
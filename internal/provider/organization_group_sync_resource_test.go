@@ -35,6 +35,12 @@ func TestAccOrganizationGroupSyncResource(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	// Mapped groups must exist in the organization.
+	group, err := client.CreateGroup(ctx, org.ID, codersdk.CreateGroupRequest{
+		Name: "test-group",
+	})
+	require.NoError(t, err)
+
 	cfg1 := testAccOrganizationGroupSyncResourceConfig{
 		URL:            client.URL.String(),
 		Token:          client.SessionToken(),
@@ -48,12 +54,12 @@ func TestAccOrganizationGroupSyncResource(t *testing.T) {
 	cfg2.RegexFilter = ptr.Ref(".*test.*")
 	cfg2.AutoCreateMissing = ptr.Ref(true)
 	cfg2.Mapping = map[string][]string{
-		"test_group": {"6e57187f-6543-46ab-a62c-a10065dd4314"},
+		"test_group": {group.ID.String()},
 	}
 
 	cfg3 := cfg2
 	cfg3.Mapping = map[string][]string{
-		"new_group": {"6e57187f-6543-46ab-a62c-a10065dd4314"},
+		"new_group": {group.ID.String()},
 	}
 
 	t.Run("CreateImportUpdateReadOk", func(t *testing.T) {
@@ -86,14 +92,14 @@ func TestAccOrganizationGroupSyncResource(t *testing.T) {
 						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("field"), knownvalue.StringExact("updated_groups")),
 						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("regex_filter"), knownvalue.StringExact(".*test.*")),
 						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("auto_create_missing"), knownvalue.Bool(true)),
-						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("mapping").AtMapKey("test_group").AtSliceIndex(0), knownvalue.StringExact("6e57187f-6543-46ab-a62c-a10065dd4314")),
+						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("mapping").AtMapKey("test_group").AtSliceIndex(0), knownvalue.StringExact(group.ID.String())),
 					},
 				},
 				// Update mapping
 				{
 					Config: cfg3.String(t),
 					ConfigStateChecks: []statecheck.StateCheck{
-						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("mapping").AtMapKey("new_group").AtSliceIndex(0), knownvalue.StringExact("6e57187f-6543-46ab-a62c-a10065dd4314")),
+						statecheck.ExpectKnownValue("coderd_organization_group_sync.test", tfjsonpath.New("mapping").AtMapKey("new_group").AtSliceIndex(0), knownvalue.StringExact(group.ID.String())),
 					},
 				},
 			},
@@ -124,6 +130,29 @@ func TestAccOrganizationGroupSyncResource(t *testing.T) {
 		})
 	})
 
+	t.Run("UnknownGroup", func(t *testing.T) {
+		unknownGroupCfg := testAccOrganizationGroupSyncResourceConfig{
+			URL:            client.URL.String(),
+			Token:          client.SessionToken(),
+			OrganizationID: org.ID.String(),
+			Field:          "groups",
+			Mapping: map[string][]string{
+				"test_group": {"6e57187f-6543-46ab-a62c-a10065dd4314"},
+			},
+		}
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      unknownGroupCfg.String(t),
+					ExpectError: regexp.MustCompile("Group 6e57187f-6543-46ab-a62c-a10065dd4314 does not exist"),
+				},
+			},
+		})
+	})
+
 	t.Run("InvalidRegexFilter", func(t *testing.T) {
 		invalidRegexCfg := testAccOrganizationGroupSyncResourceConfig{
 			URL:            client.URL.String(),
@@ -30,6 +30,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationResource{}
 var _ resource.ResourceWithImportState = &OrganizationResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationResource{}
 
 type OrganizationResource struct {
 	*CoderdProviderData
@@ -236,6 +237,45 @@ func (r *OrganizationResource) Configure(ctx context.Context, req resource.Confi
 	r.CoderdProviderData = data
 }
 
+// ModifyPlan checks that the organization's group and role sync are not
+// also managed by the standalone resources, and that their mappings refer to
+// groups and roles of the organization.
+func (r *OrganizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to check.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+
+	var data OrganizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// A new organization can't be targeted by another resource yet.
+	if resp.Diagnostics.HasError() || data.ID.IsUnknown() {
+		return
+	}
+	orgID := data.ID.ValueUUID()
+
+	if !data.GroupSync.IsNull() {
+		resp.Diagnostics.Append(r.claimOrganizationSetting("group sync", orgID, "the `group_sync` block of `coderd_organization`")...)
+		if !data.GroupSync.IsUnknown() {
+			var groupSync GroupSyncModel
+			resp.Diagnostics.Append(data.GroupSync.As(ctx, &groupSync, basetypes.ObjectAsOptions{})...)
+			resp.Diagnostics.Append(r.validateGroupSyncMapping(ctx, path.Root("group_sync").AtName("mapping"), orgID, groupSync.Mapping)...)
+		}
+	}
+	if !data.RoleSync.IsNull() {
+		resp.Diagnostics.Append(r.claimOrganizationSetting("role sync", orgID, "the `role_sync` block of `coderd_organization`")...)
+		if !data.RoleSync.IsUnknown() {
+			var roleSync RoleSyncModel
+			resp.Diagnostics.Append(data.RoleSync.As(ctx, &roleSync, basetypes.ObjectAsOptions{})...)
+			resp.Diagnostics.Append(r.validateRoleSyncMapping(ctx, path.Root("role_sync").AtName("mapping"), orgID, roleSync.Mapping)...)
+		}
+	}
+}
+
 func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Read Terraform prior state data into the model
 	var data OrganizationResourceModel
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationRoleSyncResource{}
+var _ resource.ResourceWithImportState = &OrganizationRoleSyncResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationRoleSyncResource{}
+
+type OrganizationRoleSyncResource struct {
+	*CoderdProviderData
+}
+
+// OrganizationRoleSyncResourceModel describes the resource data model.
+type OrganizationRoleSyncResourceModel struct {
+	OrganizationID UUID         `tfsdk:"organization_id"`
+	Field          types.String `tfsdk:"field"`
+	Mapping        types.Map    `tfsdk:"mapping"`
+}
+
+func NewOrganizationRoleSyncResource() resource.Resource {
+	return &OrganizationRoleSyncResource{}
+}
+
+func (r *OrganizationRoleSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_role_sync"
+}
+
+func (r *OrganizationRoleSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Role sync settings for an organization on the Coder deployment.
+Multiple instances of this resource for a single organization, or this resource together with the ` + "`role_sync`" + ` block of ` + "`coderd_organization`" + `, will conflict, and planning them fails with an error.
+
+~> **Warning**
+This resource is only compatible with Coder version [2.16.0](https://github.com/coder/coder/releases/tag/v2.16.0) and later.
+`,
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				Required:            true,
+				MarkdownDescription: "The ID of the organization to configure role sync for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"field": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The claim field that specifies what organization roles a user should be given.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"mapping": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Required:            true,
+				MarkdownDescription: "A map from OIDC group name to Coder organization role names. Roles are checked against the organization at plan time.",
+			},
+		},
+	}
+}
+
+func (r *OrganizationRoleSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+// ModifyPlan checks that no other resource manages the organization's role
+// sync, and that every mapped role exists in the organization.
+func (r *OrganizationRoleSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to check.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+
+	var data OrganizationRoleSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.OrganizationID.IsUnknown() {
+		return
+	}
+	orgID := data.OrganizationID.ValueUUID()
+
+	resp.Diagnostics.Append(r.claimOrganizationSetting("role sync", orgID, "`coderd_organization_role_sync`")...)
+	resp.Diagnostics.Append(r.validateRoleSyncMapping(ctx, path.Root("mapping"), orgID, data.Mapping)...)
+}
+
+func (r *OrganizationRoleSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data OrganizationRoleSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueUUID()
+
+	roleSync, err := r.Client.RoleIDPSyncSettings(ctx, orgID.String())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("Organization with ID %q not found. Marking resource as deleted.", orgID.String()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get organization role sync settings, got error: %s", err))
+		return
+	}
+
+	data.Field = types.StringValue(roleSync.Field)
+
+	elements := roleSync.Mapping
+	if elements == nil {
+		elements = map[string][]string{}
+	}
+	mapping, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Mapping = mapping
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationRoleSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data OrganizationRoleSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueUUID()
+
+	tflog.Trace(ctx, "creating organization role sync", map[string]any{
+		"organization_id": orgID,
+		"field":           data.Field.ValueString(),
+	})
+
+	// Apply role sync settings
+	resp.Diagnostics.Append(r.patchRoleSync(ctx, orgID, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationRoleSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data OrganizationRoleSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueUUID()
+
+	tflog.Trace(ctx, "updating organization role sync", map[string]any{
+		"organization_id": orgID,
+		"field":           data.Field.ValueString(),
+	})
+
+	resp.Diagnostics.Append(r.patchRoleSync(ctx, orgID, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationRoleSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data OrganizationRoleSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueUUID()
+
+	tflog.Trace(ctx, "deleting organization role sync", map[string]any{
+		"organization_id": orgID,
+	})
+
+	// Sending all zero-values will delete the role sync configuration
+	_, err := r.Client.PatchRoleIDPSyncSettings(ctx, orgID.String(), codersdk.RoleSyncSettings{})
+	if err != nil {
+		if isNotFound(err) {
+			// Organization doesn't exist, so role sync is already "deleted"
+			return
+		}
+		resp.Diagnostics.AddError("Role Sync Delete error", err.Error())
+		return
+	}
+}
+
+func (r *OrganizationRoleSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using organization ID
+	resource.ImportStatePassthroughID(ctx, path.Root("organization_id"), req, resp)
+}
+
+func (r *OrganizationRoleSyncResource) patchRoleSync(
+	ctx context.Context,
+	orgID uuid.UUID,
+	data OrganizationRoleSyncResourceModel,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	roleSync := codersdk.RoleSyncSettings{
+		Field:   data.Field.ValueString(),
+		Mapping: make(map[string][]string),
+	}
+	// Mapping is required, so always process it (can be empty)
+	diags.Append(data.Mapping.ElementsAs(ctx, &roleSync.Mapping, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Perform the PATCH
+	_, err := r.Client.PatchRoleIDPSyncSettings(ctx, orgID.String(), roleSync)
+	if err != nil {
+		diags.AddError("Role Sync Update error", err.Error())
+		return diags
+	}
+
+	return diags
+}
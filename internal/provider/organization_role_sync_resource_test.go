@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccOrganizationRoleSyncResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "organization_role_sync_acc", integration.UseLicense)
+
+	cfg := func(field string, mapping map[string][]string, embedded bool) string {
+		var entries strings.Builder
+		for key, roles := range mapping {
+			fmt.Fprintf(&entries, "\t\t%q = %s\n", key, strings.ReplaceAll(fmt.Sprintf("%q", roles), `" "`, `", "`))
+		}
+		roleSyncBlock := ""
+		if embedded {
+			roleSyncBlock = `
+	role_sync {
+		field   = "roles"
+		mapping = {}
+	}`
+		}
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_organization" "test" {
+	name = "role-sync-org"%s
+}
+
+resource "coderd_organization_role_sync" "test" {
+	organization_id = coderd_organization.test.id
+	field           = %q
+	mapping = {
+%s	}
+}
+`, client.URL.String(), client.SessionToken(), roleSyncBlock, field, entries.String())
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: cfg("roles", map[string][]string{}, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("coderd_organization_role_sync.test", tfjsonpath.New("field"), knownvalue.StringExact("roles")),
+					statecheck.ExpectKnownValue("coderd_organization_role_sync.test", tfjsonpath.New("mapping"), knownvalue.MapSizeExact(0)),
+				},
+			},
+			// Import
+			{
+				Config:            cfg("roles", map[string][]string{}, false),
+				ResourceName:      "coderd_organization_role_sync.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["coderd_organization.test"].Primary.ID, nil
+				},
+				ImportStateVerifyIdentifierAttribute: "organization_id",
+			},
+			// Update and Read
+			{
+				Config: cfg("coder_roles", map[string][]string{
+					"admins":   {"organization-admin"},
+					"auditors": {"organization-auditor", "organization-template-admin"},
+				}, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("coderd_organization_role_sync.test", tfjsonpath.New("field"), knownvalue.StringExact("coder_roles")),
+					statecheck.ExpectKnownValue("coderd_organization_role_sync.test", tfjsonpath.New("mapping").AtMapKey("admins").AtSliceIndex(0), knownvalue.StringExact("organization-admin")),
+					statecheck.ExpectKnownValue("coderd_organization_role_sync.test", tfjsonpath.New("mapping").AtMapKey("auditors"), knownvalue.ListSizeExact(2)),
+				},
+			},
+			// Roles must exist in the organization
+			{
+				Config: cfg("coder_roles", map[string][]string{
+					"admins": {"not-a-role"},
+				}, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"not-a-role" is not a role of organization`),
+			},
+			// The embedded block conflicts with the standalone resource
+			{
+				Config: cfg("coder_roles", map[string][]string{
+					"admins": {"organization-admin"},
+				}, true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting Organization Setting"),
+			},
+		},
+	})
+}
@@ -3,20 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationSyncSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationSyncSettingsResource{}
 
 type OrganizationSyncSettingsResource struct {
 	*CoderdProviderData
@@ -24,9 +29,10 @@ type OrganizationSyncSettingsResource struct {
 
 // OrganizationSyncSettingsResourceModel describes the resource data model.
 type OrganizationSyncSettingsResourceModel struct {
-	Field         types.String `tfsdk:"field"`
-	AssignDefault types.Bool   `tfsdk:"assign_default"`
-	Mapping       types.Map    `tfsdk:"mapping"`
+	Field          types.String `tfsdk:"field"`
+	AssignDefault  types.Bool   `tfsdk:"assign_default"`
+	Mapping        types.Map    `tfsdk:"mapping"`
+	AllowOverwrite types.Bool   `tfsdk:"allow_overwrite"`
 }
 
 func NewOrganizationSyncSettingsResource() resource.Resource {
@@ -41,12 +47,13 @@ func (r *OrganizationSyncSettingsResource) Schema(ctx context.Context, req resou
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `IdP sync settings for organizations.
 
-This resource can only be created once. Attempts to create multiple will fail.
+A deployment has one set of organization sync settings, so declare this resource at most once. If they change outside this configuration, the next plan fails unless ` + "`allow_overwrite`" + ` is set.
 
 ~> **Warning**
 This resource is only compatible with Coder version [2.19.0](https://github.com/coder/coder/releases/tag/v2.19.0) and later.
 `,
 		Attributes: map[string]schema.Attribute{
+			"allow_overwrite": allowOverwriteSchema("the organization sync settings"),
 			"field": schema.StringAttribute{
 				Required: true,
 				MarkdownDescription: "The claim field that specifies what organizations " +
@@ -124,6 +131,9 @@ func (r *OrganizationSyncSettingsResource) Read(ctx context.Context, req resourc
 		data.Mapping = mapping
 	}
 
+	resp.Diagnostics.Append(adoptSingletonFingerprint(ctx, resp.Private,
+		newOrganizationSyncFingerprint(settings, !data.Mapping.IsNull()))...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -142,10 +152,13 @@ func (r *OrganizationSyncSettingsResource) Create(ctx context.Context, req resou
 	})
 
 	// Create and Update use a shared implementation
-	resp.Diagnostics.Append(r.patch(ctx, data)...)
+	settings, diags := r.patch(ctx, data)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private,
+		newOrganizationSyncFingerprint(settings, !data.Mapping.IsNull()))...)
 
 	tflog.Trace(ctx, "successfully created organization sync", map[string]any{
 		"field":          data.Field.ValueString(),
@@ -171,10 +184,13 @@ func (r *OrganizationSyncSettingsResource) Update(ctx context.Context, req resou
 	})
 
 	// Create and Update use a shared implementation
-	resp.Diagnostics.Append(r.patch(ctx, data)...)
+	settings, diags := r.patch(ctx, data)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(recordSingletonFingerprint(ctx, resp.Private,
+		newOrganizationSyncFingerprint(settings, !data.Mapping.IsNull()))...)
 
 	tflog.Trace(ctx, "successfully updated organization", map[string]any{
 		"field":          data.Field.ValueString(),
@@ -188,51 +204,133 @@ func (r *OrganizationSyncSettingsResource) Update(ctx context.Context, req resou
 func (r *OrganizationSyncSettingsResource) patch(
 	ctx context.Context,
 	data OrganizationSyncSettingsResourceModel,
-) diag.Diagnostics {
+) (codersdk.OrganizationSyncSettings, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	field := data.Field.ValueString()
 	assignDefault := data.AssignDefault.ValueBool()
 
 	if data.Mapping.IsNull() {
-		_, err := r.Client.PatchOrganizationIDPSyncConfig(ctx, codersdk.PatchOrganizationIDPSyncConfigRequest{
+		updated, err := r.Client.PatchOrganizationIDPSyncConfig(ctx, codersdk.PatchOrganizationIDPSyncConfigRequest{
 			Field:         field,
 			AssignDefault: assignDefault,
 		})
 
 		if err != nil {
 			diags.AddError("failed to create organization sync", err.Error())
-			return diags
-		}
-	} else {
-		settings := codersdk.OrganizationSyncSettings{
-			Field:         field,
-			AssignDefault: assignDefault,
-			Mapping:       map[string][]uuid.UUID{},
 		}
+		return updated, diags
+	}
 
-		// Terraform doesn't know how to turn one our `UUID` Terraform values into a
-		// `uuid.UUID`, so we have to do the unwrapping manually here.
-		var mapping map[string][]UUID
-		diags.Append(data.Mapping.ElementsAs(ctx, &mapping, false)...)
-		if diags.HasError() {
-			return diags
-		}
-		for key, ids := range mapping {
-			for _, id := range ids {
-				settings.Mapping[key] = append(settings.Mapping[key], id.ValueUUID())
-			}
+	settings := codersdk.OrganizationSyncSettings{
+		Field:         field,
+		AssignDefault: assignDefault,
+		Mapping:       map[string][]uuid.UUID{},
+	}
+
+	// Terraform doesn't know how to turn one our `UUID` Terraform values into a
+	// `uuid.UUID`, so we have to do the unwrapping manually here.
+	var mapping map[string][]UUID
+	diags.Append(data.Mapping.ElementsAs(ctx, &mapping, false)...)
+	if diags.HasError() {
+		return settings, diags
+	}
+	for key, ids := range mapping {
+		for _, id := range ids {
+			settings.Mapping[key] = append(settings.Mapping[key], id.ValueUUID())
 		}
+	}
 
-		_, err := r.Client.PatchOrganizationIDPSyncSettings(ctx, settings)
+	updated, err := r.Client.PatchOrganizationIDPSyncSettings(ctx, settings)
+	if err != nil {
+		diags.AddError("failed to create organization sync", err.Error())
+		return settings, diags
+	}
+	return updated, diags
+}
+
+func (r *OrganizationSyncSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan, and Configure() has not run during the
+	// validate walk.
+	if req.Plan.Raw.IsNull() || r.CoderdProviderData == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.claimSingleton("coderd_organization_sync_settings")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.warnOverwrite(ctx, req.Plan)...)
+		return
+	}
+
+	var state OrganizationSyncSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkSingletonFingerprint(ctx, req.Plan, req.Private, "coderd_organization_sync_settings", func() (any, error) {
+		settings, err := r.Client.OrganizationIDPSyncSettings(ctx)
 		if err != nil {
-			diags.AddError("failed to create organization sync", err.Error())
-			return diags
+			return nil, err
 		}
-	}
+		return newOrganizationSyncFingerprint(settings, !state.Mapping.IsNull()), nil
+	})...)
+}
 
+// warnOverwrite warns when creating the resource would overwrite
+// organization sync settings that are already configured, such as by another
+// configuration. Terraform has no prior state to show the change as a diff.
+func (r *OrganizationSyncSettingsResource) warnOverwrite(ctx context.Context, plan tfsdk.Plan) (diags diag.Diagnostics) {
+	var data OrganizationSyncSettingsResourceModel
+	diags.Append(plan.Get(ctx, &data)...)
+	if diags.HasError() || data.Field.IsUnknown() {
+		return diags
+	}
+	settings, err := r.Client.OrganizationIDPSyncSettings(ctx)
+	if err != nil {
+		// This lookup is advisory; CRUD reports endpoint failures.
+		tflog.Debug(ctx, "skipping organization sync settings plan-time check", map[string]any{
+			"error": err.Error(),
+		})
+		return diags
+	}
+	if settings.Field == "" || settings.Field == data.Field.ValueString() {
+		return diags
+	}
+	diags.AddAttributeWarning(path.Root("field"), "Overwriting an out-of-band value",
+		fmt.Sprintf("Organization sync is currently configured on this deployment with the %q claim field, and applying "+
+			"will overwrite it. Terraform has no prior state for this resource, so this change is not shown as a diff. "+
+			"If another configuration manages organization sync, remove this resource instead.", settings.Field))
 	return diags
 }
 
+// organizationSyncFingerprint is the value fingerprinted for singleton
+// conflict detection. The mapping is only included when the resource manages
+// it, since `coderd_organization` resources may otherwise edit it.
+type organizationSyncFingerprint struct {
+	Field         string                 `json:"field"`
+	AssignDefault bool                   `json:"assign_default"`
+	Mapping       map[string][]uuid.UUID `json:"mapping,omitempty"`
+}
+
+func newOrganizationSyncFingerprint(settings codersdk.OrganizationSyncSettings, includeMapping bool) organizationSyncFingerprint {
+	fingerprint := organizationSyncFingerprint{
+		Field:         settings.Field,
+		AssignDefault: settings.AssignDefault,
+	}
+	if includeMapping {
+		fingerprint.Mapping = make(map[string][]uuid.UUID, len(settings.Mapping))
+		for key, ids := range settings.Mapping {
+			sorted := slices.Clone(ids)
+			slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			fingerprint.Mapping[key] = sorted
+		}
+	}
+	return fingerprint
+}
+
 func (r *OrganizationSyncSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Read Terraform prior state data into the model
 	var data OrganizationSyncSettingsResourceModel
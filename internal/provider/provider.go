@@ -44,7 +44,18 @@ type featureSnapshot struct {
 type CoderdProviderData struct {
 	Client                *codersdk.Client
 	DefaultOrganizationID uuid.UUID
+	Version               string
 	features              atomic.Pointer[featureSnapshot]
+	singletons            singletonClaims
+	siteRoles             cachedLookup[[]string]
+	loginTypes            cachedLookup[[]string]
+
+	// hasLicense is whether the deployment had a license when the features
+	// were last cached.
+	hasLicense atomic.Bool
+	// licensePending is set while a planned license hasn't been added yet,
+	// as it may grant features the cached entitlements don't.
+	licensePending atomic.Bool
 }
 
 // SetFeatures atomically replaces the cached feature entitlements.
@@ -216,8 +227,10 @@ func (p *CoderdProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	providerData := &CoderdProviderData{
 		Client:                client,
 		DefaultOrganizationID: data.DefaultOrganizationID.ValueUUID(),
+		Version:               p.version,
 	}
 	providerData.SetFeatures(entitlements.Features)
+	providerData.hasLicense.Store(entitlements.HasLicense)
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
 }
@@ -239,6 +252,15 @@ func (p *CoderdProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewDefaultAgentsModelResource,
 		NewChatSystemPromptResource,
 		NewOAuth2ProviderSettingsResource,
+		NewAppearanceResource,
+		NewNotificationsSettingsResource,
+		NewNotificationTemplateMethodResource,
+		NewUserNotificationPreferencesResource,
+		NewUserGitSSHKeyResource,
+		NewUsersResource,
+		NewOrganizationRoleSyncResource,
+		NewOAuth2AppResource,
+		NewOAuth2AppSecretResource,
 	}
 }
 
@@ -248,6 +270,15 @@ func (p *CoderdProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewUserDataSource,
 		NewOrganizationDataSource,
 		NewTemplateDataSource,
+		NewTemplateVersionsDataSource,
+		NewWorkspacesDataSource,
+		NewUserQuotaDataSource,
+		NewEntitlementsDataSource,
+		NewWorkspaceProxiesDataSource,
+		NewWorkspaceProxyDataSource,
+		NewExternalAuthProvidersDataSource,
+		NewUserExternalAuthDataSource,
+		NewAIProviderModelsDataSource,
 	}
 }
 
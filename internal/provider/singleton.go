@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Deployment-wide settings have no identity of their own, so nothing stops
+// several resources, in one configuration or across several, from managing
+// the same one and silently overwriting each other. Singleton resources guard
+// against this at plan time in two ways:
+//
+//   - Within a configuration, each resource claims its type on the provider
+//     data during ModifyPlan. A second claim in the same plan proves there are
+//     two owners, and is an error.
+//   - Across configurations, each resource records a fingerprint of the value
+//     it last applied in private state. If the live value no longer matches
+//     on a later plan, something else has written it since, and the plan
+//     fails. That may be another configuration, but also an administrator
+//     using the dashboard, so `allow_overwrite` downgrades the error to a
+//     warning. A write that leaves the same value in place, which is likely
+//     for boolean settings, doesn't change the fingerprint and goes
+//     unnoticed.
+
+// singletonFingerprintKey is the private state key holding the fingerprint of
+// the value a singleton resource last applied.
+const singletonFingerprintKey = "singleton_fingerprint"
+
+// allowOverwriteAttribute is the name of the attribute that lets a singleton
+// resource plan over a change made elsewhere.
+const allowOverwriteAttribute = "allow_overwrite"
+
+// allowOverwriteSchema returns the `allow_overwrite` attribute of a singleton
+// resource. setting names what the resource manages, such as "the appearance
+// settings".
+func allowOverwriteSchema(setting string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Whether to plan over a change to %s made since this configuration last applied it, "+
+			"such as in the dashboard or by another configuration, with a warning instead of an error. Applying overwrites "+
+			"the change. A change that leaves the same value in place can't be detected. Defaults to false.", setting),
+		Optional: true,
+	}
+}
+
+// singletonClaims tracks which singleton resource types are being planned by
+// the current provider instance. Terraform configures a fresh provider for
+// every walk, so claims never outlive a single plan or apply.
+type singletonClaims struct {
+	mu      sync.Mutex
+	claimed map[string]struct{}
+	// owners maps an organization setting to the resource that claimed it.
+	owners map[string]string
+}
+
+// claimSingleton records that a resource of the given type is planned in this
+// configuration, and errors if another resource already claimed it.
+func (d *CoderdProviderData) claimSingleton(typeName string) (diags diag.Diagnostics) {
+	d.singletons.mu.Lock()
+	defer d.singletons.mu.Unlock()
+	if d.singletons.claimed == nil {
+		d.singletons.claimed = make(map[string]struct{})
+	}
+	if _, ok := d.singletons.claimed[typeName]; ok {
+		diags.AddError(
+			"Duplicate Singleton Resource",
+			fmt.Sprintf("`%s` manages a deployment-wide setting, and more than one instance of it is declared for this "+
+				"provider. Each instance would overwrite the others on apply. Declare it exactly once.", typeName),
+		)
+		return diags
+	}
+	d.singletons.claimed[typeName] = struct{}{}
+	return diags
+}
+
+// claimOrganizationSetting is claimSingleton for a setting that exists once
+// per organization and that more than one resource type can manage, such as
+// role sync, which both `coderd_organization_role_sync` and the `role_sync`
+// block of `coderd_organization` configure. claimant describes the resource
+// making the claim.
+func (d *CoderdProviderData) claimOrganizationSetting(setting string, orgID uuid.UUID, claimant string) (diags diag.Diagnostics) {
+	d.singletons.mu.Lock()
+	defer d.singletons.mu.Unlock()
+	if d.singletons.owners == nil {
+		d.singletons.owners = make(map[string]string)
+	}
+	key := setting + "/" + orgID.String()
+	if owner, ok := d.singletons.owners[key]; ok {
+		diags.AddError(
+			"Conflicting Organization Setting",
+			fmt.Sprintf("The %s settings of organization %s are managed by both %s and %s. Each would overwrite the "+
+				"other on apply. Manage them in exactly one place.", setting, orgID, owner, claimant),
+		)
+		return diags
+	}
+	d.singletons.owners[key] = claimant
+	return diags
+}
+
+// singletonFingerprint hashes the JSON encoding of live, the resource's view
+// of the setting's current value.
+func singletonFingerprint(live any) (string, error) {
+	data, err := json.Marshal(live)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// recordSingletonFingerprint stores the fingerprint of a value the resource
+// has just applied or adopted.
+func recordSingletonFingerprint(ctx context.Context, ps privateState, live any) (diags diag.Diagnostics) {
+	fingerprint, err := singletonFingerprint(live)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to fingerprint singleton value: %s", err))
+		return diags
+	}
+	return ps.SetKey(ctx, singletonFingerprintKey, []byte(fingerprint))
+}
+
+// adoptSingletonFingerprint records live as the baseline when no fingerprint
+// exists yet, which is the case right after an import or for state written by
+// a provider version that predates fingerprints. Read calls this rather than
+// recordSingletonFingerprint, since refreshing must not hide a foreign write.
+func adoptSingletonFingerprint(ctx context.Context, ps privateState, live any) (diags diag.Diagnostics) {
+	existing, diags := ps.GetKey(ctx, singletonFingerprintKey)
+	if diags.HasError() || existing != nil {
+		return diags
+	}
+	return recordSingletonFingerprint(ctx, ps, live)
+}
+
+// checkSingletonFingerprint errors if the live value, fetched with readLive,
+// no longer matches the value the resource last applied, or only warns if the
+// plan sets `allow_overwrite`. readLive is only called when a fingerprint has
+// been recorded. A failed lookup skips the check, since CRUD reports endpoint
+// failures with better context.
+func checkSingletonFingerprint(ctx context.Context, plan tfsdk.Plan, ps privateState, typeName string, readLive func() (any, error)) (diags diag.Diagnostics) {
+	existing, diags := ps.GetKey(ctx, singletonFingerprintKey)
+	if diags.HasError() || existing == nil {
+		return diags
+	}
+	live, err := readLive()
+	if err != nil {
+		tflog.Debug(ctx, "skipping singleton conflict check", map[string]any{
+			"type":  typeName,
+			"error": err.Error(),
+		})
+		return diags
+	}
+	fingerprint, err := singletonFingerprint(live)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to fingerprint singleton value: %s", err))
+		return diags
+	}
+	if fingerprint == string(existing) {
+		return diags
+	}
+
+	var allowOverwrite types.Bool
+	diags.Append(plan.GetAttribute(ctx, path.Root(allowOverwriteAttribute), &allowOverwrite)...)
+	if diags.HasError() {
+		return diags
+	}
+	changed := fmt.Sprintf("The deployment-wide setting managed by `%s` has changed since this configuration last applied it, "+
+		"either in the dashboard or because another Terraform configuration manages the same setting. ", typeName)
+	removed := "If another configuration manages it, remove the resource from this configuration with a `removed` block " +
+		"so the other configuration's value is left in place."
+	if allowOverwrite.ValueBool() {
+		diags.AddAttributeWarning(path.Root(allowOverwriteAttribute), "Singleton Changed Elsewhere",
+			changed+"Applying will overwrite the change, since `allow_overwrite` is set.\n\n"+removed)
+		return diags
+	}
+	diags.AddError("Singleton Changed Elsewhere",
+		changed+"Applying would overwrite the change. Only one configuration should manage it.\n\n"+removed+
+			" To overwrite the change instead, set `allow_overwrite = true`.")
+	return diags
+}
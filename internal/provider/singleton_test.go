@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// mapPrivateState is an in-memory privateState for unit tests.
+type mapPrivateState map[string][]byte
+
+func (m mapPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return m[key], nil
+}
+
+func (m mapPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	m[key] = value
+	return nil
+}
+
+// singletonPlan returns the plan of a singleton resource whose only attribute
+// is `allow_overwrite`, set to allowOverwrite, or null if it's nil.
+func singletonPlan(allowOverwrite any) tfsdk.Plan {
+	return tfsdk.Plan{
+		Schema: schema.Schema{Attributes: map[string]schema.Attribute{
+			allowOverwriteAttribute: allowOverwriteSchema("the setting"),
+		}},
+		Raw: tftypes.NewValue(
+			tftypes.Object{AttributeTypes: map[string]tftypes.Type{allowOverwriteAttribute: tftypes.Bool}},
+			map[string]tftypes.Value{allowOverwriteAttribute: tftypes.NewValue(tftypes.Bool, allowOverwrite)},
+		),
+	}
+}
+
+func TestClaimSingleton(t *testing.T) {
+	t.Parallel()
+
+	data := &CoderdProviderData{}
+	require.False(t, data.claimSingleton("coderd_a").HasError())
+	require.False(t, data.claimSingleton("coderd_b").HasError())
+
+	diags := data.claimSingleton("coderd_a")
+	require.True(t, diags.HasError())
+	require.Equal(t, "Duplicate Singleton Resource", diags.Errors()[0].Summary())
+
+	// A new provider instance starts with no claims.
+	require.False(t, (&CoderdProviderData{}).claimSingleton("coderd_a").HasError())
+}
+
+func TestCheckSingletonFingerprint(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	liveValue := func(v any) func() (any, error) {
+		return func() (any, error) { return v, nil }
+	}
+
+	t.Run("NoFingerprintSkipsLookup", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		diags := checkSingletonFingerprint(ctx, singletonPlan(nil), mapPrivateState{}, "coderd_a", func() (any, error) {
+			called = true
+			return nil, nil
+		})
+		require.False(t, diags.HasError())
+		require.False(t, called)
+	})
+
+	t.Run("MatchingValue", func(t *testing.T) {
+		t.Parallel()
+		ps := mapPrivateState{}
+		require.False(t, recordSingletonFingerprint(ctx, ps, true).HasError())
+		require.Empty(t, checkSingletonFingerprint(ctx, singletonPlan(nil), ps, "coderd_a", liveValue(true)))
+	})
+
+	t.Run("ForeignWrite", func(t *testing.T) {
+		t.Parallel()
+		ps := mapPrivateState{}
+		require.False(t, recordSingletonFingerprint(ctx, ps, true).HasError())
+		diags := checkSingletonFingerprint(ctx, singletonPlan(nil), ps, "coderd_a", liveValue(false))
+		require.True(t, diags.HasError())
+		require.Equal(t, "Singleton Changed Elsewhere", diags.Errors()[0].Summary())
+		require.Contains(t, diags.Errors()[0].Detail(), "allow_overwrite")
+	})
+
+	t.Run("ForeignWriteAllowed", func(t *testing.T) {
+		t.Parallel()
+		ps := mapPrivateState{}
+		require.False(t, recordSingletonFingerprint(ctx, ps, true).HasError())
+		diags := checkSingletonFingerprint(ctx, singletonPlan(true), ps, "coderd_a", liveValue(false))
+		require.False(t, diags.HasError())
+		require.Len(t, diags.Warnings(), 1)
+		require.Equal(t, "Singleton Changed Elsewhere", diags.Warnings()[0].Summary())
+	})
+
+	t.Run("LookupFailureSkipsCheck", func(t *testing.T) {
+		t.Parallel()
+		ps := mapPrivateState{}
+		require.False(t, recordSingletonFingerprint(ctx, ps, true).HasError())
+		diags := checkSingletonFingerprint(ctx, singletonPlan(nil), ps, "coderd_a", func() (any, error) {
+			return nil, errors.New("forbidden")
+		})
+		require.False(t, diags.HasError())
+	})
+
+	t.Run("AdoptKeepsExistingFingerprint", func(t *testing.T) {
+		t.Parallel()
+		ps := mapPrivateState{}
+		require.False(t, adoptSingletonFingerprint(ctx, ps, true).HasError())
+		// Refreshing after a foreign write must not hide it.
+		require.False(t, adoptSingletonFingerprint(ctx, ps, false).HasError())
+		require.True(t, checkSingletonFingerprint(ctx, singletonPlan(nil), ps, "coderd_a", liveValue(false)).HasError())
+	})
+}
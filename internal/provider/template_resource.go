@@ -17,6 +17,7 @@ import (
 	"github.com/coder/retry"
 	"github.com/coder/terraform-provider-coderd/internal/codersdkvalidator"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -44,10 +45,20 @@ var (
 	_ resource.Resource                     = &TemplateResource{}
 	_ resource.ResourceWithImportState      = &TemplateResource{}
 	_ resource.ResourceWithConfigValidators = &TemplateResource{}
+	_ resourceWithEntitlements              = &TemplateResource{}
 )
 
 const templateAgentsAllowedMinVersion = "2.37.0"
 
+// Values of the `on_destroy` attribute.
+const (
+	templateOnDestroyFailIfInUse = "fail_if_in_use"
+	templateOnDestroyDeprecate   = "deprecate"
+	templateOnDestroyForce       = "force"
+)
+
+const defaultTemplateDeprecationMessage = "This template is no longer maintained."
+
 func NewTemplateResource() resource.Resource {
 	return &TemplateResource{}
 }
@@ -84,8 +95,12 @@ type TemplateResourceModel struct {
 	AgentsAllowed                  types.Bool   `tfsdk:"agents_allowed"`
 
 	// If null, we are not managing ACL via Terraform (such as for AGPL).
-	ACL      types.Object `tfsdk:"acl"`
-	Versions Versions     `tfsdk:"versions"`
+	ACL              types.Object `tfsdk:"acl"`
+	Versions         Versions     `tfsdk:"versions"`
+	VersionRetention types.Object `tfsdk:"version_retention"`
+
+	OnDestroy             types.String `tfsdk:"on_destroy"`
+	MarkWorkspacesDormant types.Bool   `tfsdk:"mark_workspaces_dormant"`
 }
 
 // EqualTemplateMetadata returns true if two templates have identical metadata (excluding ACL).
@@ -113,53 +128,46 @@ func (m *TemplateResourceModel) EqualTemplateMetadata(other *TemplateResourceMod
 		m.AgentsAllowed.Equal(other.AgentsAllowed)
 }
 
-func (m *TemplateResourceModel) CheckEntitlements(ctx context.Context, features map[codersdk.FeatureName]codersdk.Feature) (diags diag.Diagnostics) {
-	var autoStop AutostopRequirement
-	diags.Append(m.AutostopRequirement.As(ctx, &autoStop, basetypes.ObjectAsOptions{})...)
-	if diags.HasError() {
-		return diags
-	}
-	requiresScheduling := len(autoStop.DaysOfWeek) > 0 ||
-		!m.AllowUserAutostart.ValueBool() ||
-		!m.AllowUserAutostop.ValueBool() ||
-		m.FailureTTLMillis.ValueInt64() != 0 ||
-		m.TimeTilDormantAutoDeleteMillis.ValueInt64() != 0 ||
-		m.TimeTilDormantMillis.ValueInt64() != 0 ||
-		len(m.AutostartPermittedDaysOfWeek.Elements()) != 7
-	requiresActiveVersion := m.RequireActiveVersion.ValueBool()
-	requiresACL := !m.ACL.IsNull()
-	requiresSharedPortsControl := m.MaxPortShareLevel.ValueString() != "" && m.MaxPortShareLevel.ValueString() != string(codersdk.WorkspaceAgentPortShareLevelPublic)
-	if requiresScheduling || requiresActiveVersion || requiresACL || requiresSharedPortsControl {
-		if requiresScheduling && !features[codersdk.FeatureAdvancedTemplateScheduling].Enabled {
-			diags.AddError(
-				"Feature not enabled",
-				"Your license is not entitled to use advanced template scheduling, so you cannot modify any of the following fields from their defaults: auto_stop_requirement, auto_start_permitted_days_of_week, allow_user_auto_start, allow_user_auto_stop, failure_ttl_ms, time_til_dormant_ms, time_til_dormant_autodelete_ms.",
-			)
-			return
-		}
-		if requiresActiveVersion && !features[codersdk.FeatureAccessControl].Enabled {
-			diags.AddError(
-				"Feature not enabled",
-				"Your license is not entitled to use access control, so you cannot set require_active_version.",
-			)
-			return
-		}
-		if requiresACL && !features[codersdk.FeatureTemplateRBAC].Enabled {
-			diags.AddError(
-				"Feature not enabled",
-				"Your license is not entitled to use template access control, so you cannot set acl.",
-			)
-			return
-		}
-		if requiresSharedPortsControl && !features[codersdk.FeatureControlSharedPorts].Enabled {
-			diags.AddError(
-				"Feature not enabled",
-				"Your license is not entitled to use port sharing control, so you cannot set max_port_share_level.",
-			)
-			return
+// Entitlements implements resourceWithEntitlements.
+func (r *TemplateResource) Entitlements() []entitlement {
+	scheduling := func(p path.Path, requires func(attr.Value) bool) entitlement {
+		return entitlement{
+			Feature:   codersdk.FeatureAdvancedTemplateScheduling,
+			Attribute: p,
+			Requires:  requires,
+			Usage:     fmt.Sprintf("use advanced template scheduling, so you cannot change %s from its default", p),
 		}
 	}
-	return
+	return []entitlement{
+		scheduling(path.Root("auto_stop_requirement").AtName("days_of_week"), func(v attr.Value) bool {
+			return len(v.(types.Set).Elements()) > 0
+		}),
+		scheduling(path.Root("auto_start_permitted_days_of_week"), func(v attr.Value) bool {
+			return len(v.(types.Set).Elements()) != 7
+		}),
+		scheduling(path.Root("allow_user_auto_start"), differsFrom(types.BoolValue(true))),
+		scheduling(path.Root("allow_user_auto_stop"), differsFrom(types.BoolValue(true))),
+		scheduling(path.Root("failure_ttl_ms"), differsFrom(types.Int64Value(0))),
+		scheduling(path.Root("time_til_dormant_ms"), differsFrom(types.Int64Value(0))),
+		scheduling(path.Root("time_til_dormant_autodelete_ms"), differsFrom(types.Int64Value(0))),
+		{
+			Feature:   codersdk.FeatureAccessControl,
+			Attribute: path.Root("require_active_version"),
+			Requires:  differsFrom(types.BoolValue(false)),
+			Usage:     "use access control, so you cannot set require_active_version",
+		},
+		{
+			Feature:   codersdk.FeatureTemplateRBAC,
+			Attribute: path.Root("acl"),
+			Usage:     "use template access control, so you cannot set acl",
+		},
+		{
+			Feature:   codersdk.FeatureControlSharedPorts,
+			Attribute: path.Root("max_port_share_level"),
+			Requires:  differsFrom(types.StringValue(string(codersdk.WorkspaceAgentPortShareLevelPublic))),
+			Usage:     "use port sharing control, so you cannot set max_port_share_level",
+		},
+	}
 }
 
 type TemplateVersion struct {
@@ -167,6 +175,7 @@ type TemplateVersion struct {
 	Name               types.String `tfsdk:"name"`
 	Message            types.String `tfsdk:"message"`
 	Directory          types.String `tfsdk:"directory"`
+	SourceVersionID    UUID         `tfsdk:"source_version_id"`
 	DirectoryHash      types.String `tfsdk:"directory_hash"`
 	Active             types.Bool   `tfsdk:"active"`
 	TerraformVariables types.Set    `tfsdk:"tf_vars"`
@@ -280,6 +289,12 @@ var permissionTypeAttr = basetypes.SetType{ElemType: types.ObjectType{
 	},
 }}
 
+type VersionRetention struct {
+	KeepLast      types.Int64  `tfsdk:"keep_last"`
+	KeepNewerThan types.String `tfsdk:"keep_newer_than"`
+	KeepInUse     types.Bool   `tfsdk:"keep_in_use"`
+}
+
 type AutostopRequirement struct {
 	DaysOfWeek []string `tfsdk:"days_of_week"`
 	Weeks      int64    `tfsdk:"weeks"`
@@ -515,8 +530,16 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 							Default:             stringdefault.StaticString(""),
 						},
 						"directory": schema.StringAttribute{
-							MarkdownDescription: "A path to the directory to create the template version from. Changes in the directory contents will trigger the creation of a new template version.",
-							Required:            true,
+							MarkdownDescription: "A path to the directory to create the template version from. Changes in the directory contents will trigger the creation of a new template version. Exactly one of `directory` or `source_version_id` must be set.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_version_id")),
+							},
+						},
+						"source_version_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of an existing template version on the same deployment to create this version from. The source version's uploaded files are reused as-is, so a version tested in one template or organization can be promoted without re-uploading. The source version's variable values are copied too, except sensitive ones, which the deployment doesn't return and must be set with `tf_vars`. Values in `tf_vars` take precedence. Changing this value will trigger the creation of a new template version.",
+							CustomType:          UUIDType,
+							Optional:            true,
 						},
 						"directory_hash": schema.StringAttribute{
 							Computed: true,
@@ -543,6 +566,47 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 					NewVersionsPlanModifier(),
 				},
 			},
+			"version_retention": schema.SingleNestedAttribute{
+				MarkdownDescription: "Archives old versions of the template after each create or update. The active version, versions listed in `versions`, and versions matched by any of the rules below are kept; every other unarchived version of the template is archived. If null, versions are never archived.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"keep_last": schema.Int64Attribute{
+						MarkdownDescription: "Keep the N most recently created versions.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"keep_newer_than": schema.StringAttribute{
+						MarkdownDescription: "Keep versions created within this duration of the apply, as a Go duration string such as `720h`.",
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"keep_in_use": schema.BoolAttribute{
+						MarkdownDescription: "Keep versions used by the latest build of an existing workspace, so stopped workspaces can still be started. Defaults to true.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+					},
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What to do when the resource is destroyed while workspaces still use the template. `fail_if_in_use` fails the destroy and lists the remaining workspaces. `deprecate` deprecates the template instead of deleting it, using `deprecation_message` if set, and removes it from the Terraform state. `force` deletes the remaining workspaces before deleting the template. Templates without workspaces are always deleted. Defaults to `fail_if_in_use`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(templateOnDestroyFailIfInUse),
+				Validators: []validator.String{
+					stringvalidator.OneOf(templateOnDestroyFailIfInUse, templateOnDestroyDeprecate, templateOnDestroyForce),
+				},
+			},
+			"mark_workspaces_dormant": schema.BoolAttribute{
+				MarkdownDescription: "Whether to mark the remaining workspaces as dormant when the template is deprecated by `on_destroy = \"deprecate\"`. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -567,6 +631,14 @@ func (r *TemplateResource) Configure(ctx context.Context, req resource.Configure
 	r.data = data
 }
 
+func (r *TemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Configure() has not run during the validate walk.
+	if r.data == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.data.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+}
+
 func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TemplateResourceModel
 
@@ -576,6 +648,11 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// The "at least one version is required when creating" requirement is
 	// enforced at plan-time by versionsPlanModifier.PlanModifyList, so it
 	// doesn't need to be repeated here.
@@ -588,11 +665,6 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		data.DisplayName = data.Name
 	}
 
-	resp.Diagnostics.Append(data.CheckEntitlements(ctx, r.data.Features())...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	client := r.data.Client
 	orgID := data.OrganizationID.ValueUUID()
 	var templateResp codersdk.Template
@@ -710,6 +782,8 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	data.reconcileVersionedMetadata(&authoritativeTemplate)
 
+	resp.Diagnostics.Append(r.pruneVersions(ctx, &data, authoritativeTemplate.ActiveVersionID)...)
+
 	resp.Diagnostics.Append(data.Versions.setPrivateState(ctx, resp.Private)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -750,6 +824,14 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 	data.reconcileVersionedMetadata(&template)
 
+	// Imported resources have no destroy behavior in state yet.
+	if data.OnDestroy.IsNull() {
+		data.OnDestroy = types.StringValue(templateOnDestroyFailIfInUse)
+	}
+	if data.MarkWorkspacesDormant.IsNull() {
+		data.MarkWorkspacesDormant = types.BoolValue(false)
+	}
+
 	if !data.ACL.IsNull() {
 		tflog.Info(ctx, "reading template ACL")
 		acl, err := client.TemplateACL(ctx, templateID)
@@ -798,6 +880,11 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &curState)...)
 	if resp.Diagnostics.HasError() {
@@ -812,11 +899,6 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		newState.DisplayName = newState.Name
 	}
 
-	resp.Diagnostics.Append(newState.CheckEntitlements(ctx, r.data.Features())...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	orgID := newState.OrganizationID.ValueUUID()
 
 	templateID := newState.ID.ValueUUID()
@@ -925,6 +1007,8 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	newState.reconcileVersionedMetadata(&templateResp)
 
+	resp.Diagnostics.Append(r.pruneVersions(ctx, &newState, templateResp.ActiveVersionID)...)
+
 	resp.Diagnostics.Append(newState.Versions.setPrivateState(ctx, resp.Private)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -948,14 +1032,89 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	templateID := data.ID.ValueUUID()
 
+	workspaces, err := templateWorkspaces(ctx, client, templateID, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list workspaces using the template: %s", err))
+		return
+	}
+
+	if len(workspaces) > 0 {
+		switch data.OnDestroy.ValueString() {
+		case templateOnDestroyDeprecate:
+			resp.Diagnostics.Append(r.deprecate(ctx, &data, workspaces)...)
+			return
+		case templateOnDestroyForce:
+			for _, workspace := range workspaces {
+				tflog.Info(ctx, "deleting workspace", map[string]any{
+					"workspace_id": workspace.ID.String(),
+				})
+				err := buildWorkspace(ctx, client, workspace.ID, codersdk.WorkspaceTransitionDelete)
+				if err != nil {
+					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete workspace %s: %s", workspaceDisplayName(workspace), err))
+					return
+				}
+			}
+		default:
+			resp.Diagnostics.AddError("Template In Use",
+				fmt.Sprintf("The template cannot be deleted while workspaces still use it. Delete the workspaces below, or set `on_destroy` to "+
+					"`deprecate` or `force`:\n%s", formatWorkspaceList(workspaces)))
+			return
+		}
+	}
+
 	tflog.Info(ctx, "deleting template")
-	err := client.DeleteTemplate(ctx, templateID)
+	err = client.DeleteTemplate(ctx, templateID)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete template: %s", err))
 		return
 	}
 }
 
+// deprecate deprecates the template in place of deleting it, optionally
+// marking the workspaces still using it as dormant. Returning without errors
+// removes the resource from state, leaving the template unmanaged.
+func (r *TemplateResource) deprecate(ctx context.Context, data *TemplateResourceModel, workspaces []codersdk.Workspace) (diags diag.Diagnostics) {
+	client := r.data.Client
+
+	message := data.DeprecationMessage.ValueString()
+	if message == "" {
+		message = defaultTemplateDeprecationMessage
+	}
+	data.DeprecationMessage = types.StringValue(message)
+	updateReq := data.toUpdateRequest(ctx, &diags)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Info(ctx, "deprecating template")
+	_, err := client.UpdateTemplateMeta(ctx, data.ID.ValueUUID(), *updateReq)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to deprecate template: %s", err))
+		return diags
+	}
+
+	if data.MarkWorkspacesDormant.ValueBool() {
+		for _, workspace := range workspaces {
+			if workspace.DormantAt != nil {
+				continue
+			}
+			tflog.Info(ctx, "marking workspace as dormant", map[string]any{
+				"workspace_id": workspace.ID.String(),
+			})
+			err := client.UpdateWorkspaceDormancy(ctx, workspace.ID, codersdk.UpdateWorkspaceDormancy{
+				Dormant: true,
+			})
+			if err != nil {
+				diags.AddWarning("Client Warning", fmt.Sprintf("Failed to mark workspace %s as dormant: %s", workspaceDisplayName(workspace), err))
+			}
+		}
+	}
+
+	diags.AddWarning("Template Deprecated",
+		fmt.Sprintf("The template was deprecated instead of deleted, and is no longer managed by Terraform. "+
+			"Delete it once the workspaces below have been removed:\n%s", formatWorkspaceList(workspaces)))
+	return diags
+}
+
 func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, "/")
 	if len(idParts) == 1 {
@@ -1104,6 +1263,14 @@ func (d *versionsPlanModifier) PlanModifyList(ctx context.Context, req planmodif
 	}
 
 	for i := range planVersions {
+		if planVersions[i].SourceVersionID.IsUnknown() {
+			planVersions[i].DirectoryHash = types.StringUnknown()
+			continue
+		}
+		if !planVersions[i].SourceVersionID.IsNull() {
+			planVersions[i].DirectoryHash = types.StringValue(computeSourceVersionHash(planVersions[i].SourceVersionID.ValueUUID()))
+			continue
+		}
 		hash, err := computeDirectoryHash(planVersions[i].Directory.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to compute directory hash: %s", err))
@@ -1161,7 +1328,8 @@ func (d *versionsPlanModifier) PlanModifyList(ctx context.Context, req planmodif
 		attrs["name"] = planVersions[i].Name
 		attrs["directory_hash"] = planVersions[i].DirectoryHash
 
-		// tf_vars, provisioner_tags, directory, active, message — all untouched
+		// tf_vars, provisioner_tags, directory, source_version_id, active,
+		// message — all untouched
 
 		newObj, objDiag := types.ObjectValue(attrTypes, attrs)
 		if objDiag.HasError() {
@@ -1201,6 +1369,134 @@ func NewVersionsPlanModifier() planmodifier.List {
 
 var _ planmodifier.List = &versionsPlanModifier{}
 
+// pruneVersions applies the version_retention policy, archiving every version
+// of the template it does not keep. Archiving happens after the template has
+// been applied, so failures are reported as warnings rather than errors.
+func (r *TemplateResource) pruneVersions(ctx context.Context, data *TemplateResourceModel, activeVersionID uuid.UUID) (diags diag.Diagnostics) {
+	if data.VersionRetention.IsNull() || data.VersionRetention.IsUnknown() {
+		return diags
+	}
+	var retention VersionRetention
+	diags.Append(data.VersionRetention.As(ctx, &retention, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	client := r.data.Client
+	templateID := data.ID.ValueUUID()
+
+	keep := map[uuid.UUID]struct{}{
+		activeVersionID: {},
+	}
+	for _, version := range data.Versions {
+		keep[version.ID.ValueUUID()] = struct{}{}
+	}
+	if retention.KeepInUse.ValueBool() {
+		workspaces, err := templateWorkspaces(ctx, client, templateID, data.Name.ValueString())
+		if err != nil {
+			diags.AddWarning("Template Version Pruning Failed", fmt.Sprintf("Unable to list workspaces using the template, no versions were archived: %s", err))
+			return diags
+		}
+		for _, workspace := range workspaces {
+			keep[workspace.LatestBuild.TemplateVersionID] = struct{}{}
+		}
+	}
+
+	var cutoff time.Time
+	if !retention.KeepNewerThan.IsNull() {
+		// Validated at plan time by durationValidator.
+		keepNewerThan, _ := time.ParseDuration(retention.KeepNewerThan.ValueString())
+		cutoff = time.Now().Add(-keepNewerThan)
+	}
+
+	versions, err := listTemplateVersions(ctx, client, templateID, false)
+	if err != nil {
+		diags.AddWarning("Template Version Pruning Failed", fmt.Sprintf("Unable to list template versions, no versions were archived: %s", err))
+		return diags
+	}
+
+	for _, versionID := range versionsToArchive(versions, keep, int(retention.KeepLast.ValueInt64()), cutoff) {
+		tflog.Info(ctx, "archiving template version", map[string]any{
+			"version_id": versionID.String(),
+		})
+		err := client.SetArchiveTemplateVersion(ctx, versionID, true)
+		if err != nil {
+			diags.AddWarning("Template Version Pruning Failed", fmt.Sprintf("Unable to archive template version %s: %s", versionID, err))
+		}
+	}
+	return diags
+}
+
+// versionsToArchive returns the IDs of the versions not retained by the
+// policy. versions must be ordered oldest first, as returned by the API.
+func versionsToArchive(versions []codersdk.TemplateVersion, keep map[uuid.UUID]struct{}, keepLast int, cutoff time.Time) []uuid.UUID {
+	var archive []uuid.UUID
+	for i, version := range versions {
+		if _, ok := keep[version.ID]; ok {
+			continue
+		}
+		if len(versions)-i <= keepLast {
+			continue
+		}
+		if !cutoff.IsZero() && version.CreatedAt.After(cutoff) {
+			continue
+		}
+		archive = append(archive, version.ID)
+	}
+	return archive
+}
+
+// templateWorkspaces returns every workspace using the template.
+func templateWorkspaces(ctx context.Context, client *codersdk.Client, templateID uuid.UUID, templateName string) ([]codersdk.Workspace, error) {
+	workspaces, err := listWorkspaces(ctx, client, codersdk.WorkspaceFilter{
+		Template: templateName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Template names are only unique within an organization.
+	return slices.DeleteFunc(workspaces, func(workspace codersdk.Workspace) bool {
+		return workspace.TemplateID != templateID
+	}), nil
+}
+
+// buildWorkspace starts a build with the given transition for the workspace
+// and waits for it to finish.
+func buildWorkspace(ctx context.Context, client *codersdk.Client, workspaceID uuid.UUID, transition codersdk.WorkspaceTransition) error {
+	build, err := client.CreateWorkspaceBuild(ctx, workspaceID, codersdk.CreateWorkspaceBuildRequest{
+		Transition: transition,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start %s build: %w", transition, err)
+	}
+	for retrier := retry.New(500*time.Millisecond, 5*time.Second); retrier.Wait(ctx); {
+		build, err = client.WorkspaceBuild(ctx, build.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get %s build: %w", transition, err)
+		}
+		if build.Job.Status.Active() {
+			continue
+		}
+		if build.Job.Status != codersdk.ProvisionerJobSucceeded {
+			return fmt.Errorf("%s build did not succeed: %s (%s)", transition, build.Job.Status, build.Job.Error)
+		}
+		return nil
+	}
+	return ctx.Err()
+}
+
+func workspaceDisplayName(workspace codersdk.Workspace) string {
+	return workspace.OwnerName + "/" + workspace.Name
+}
+
+func formatWorkspaceList(workspaces []codersdk.Workspace) string {
+	var sb strings.Builder
+	for _, workspace := range workspaces {
+		fmt.Fprintf(&sb, "  - %s (%s)\n", workspaceDisplayName(workspace), workspace.ID)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
 var weekValidator = setvalidator.ValueStringsAre(
 	stringvalidator.OneOf("monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"),
 )
@@ -1210,6 +1506,33 @@ var templateACLRoleValidator = stringvalidator.OneOf(
 	string(codersdk.TemplateRoleUse),
 )
 
+// durationValidator checks that a string parses as a Go duration.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (durationValidator) Description(context.Context) string {
+	return "value must be a duration, such as `24h` or `90m`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", fmt.Sprintf("%q is not a valid duration: %s", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if d < 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", fmt.Sprintf("%q must not be negative.", req.ConfigValue.ValueString()))
+	}
+}
+
 func uploadDirectory(ctx context.Context, client *codersdk.Client, logger slog.Logger, directory string) (*codersdk.UploadResponse, error) {
 	pipeReader, pipeWriter := io.Pipe()
 	go func() {
@@ -1307,30 +1630,68 @@ type newVersionRequest struct {
 
 func newVersion(ctx context.Context, client *codersdk.Client, req newVersionRequest) (*codersdk.TemplateVersion, []codersdk.ProvisionerJobLog, error) {
 	var logs []codersdk.ProvisionerJobLog
-	directory := req.Version.Directory.ValueString()
-	tflog.Info(ctx, "uploading directory")
-	uploadResp, err := uploadDirectory(ctx, client, slog.Make(newTFLogSink(ctx)), directory)
-	if err != nil {
-		return nil, logs, fmt.Errorf("failed to upload directory: %s", err)
-	}
-	tflog.Info(ctx, "successfully uploaded directory")
-	tflog.Info(ctx, "discovering and parsing vars files")
-	varFiles, err := codersdk.DiscoverVarsFiles(directory)
-	if err != nil {
-		return nil, logs, fmt.Errorf("failed to discover vars files: %s", err)
-	}
-	vars, err := codersdk.ParseUserVariableValues(varFiles, "", []string{})
-	if err != nil {
-		return nil, logs, fmt.Errorf("failed to parse user variable values: %s", err)
+	var fileID uuid.UUID
+	var vars []codersdk.VariableValue
+	if !req.Version.SourceVersionID.IsNull() {
+		sourceID := req.Version.SourceVersionID.ValueUUID()
+		tflog.Info(ctx, "reusing files from source template version", map[string]any{
+			"source_version_id": sourceID.String(),
+		})
+		source, err := client.TemplateVersion(ctx, sourceID)
+		if err != nil {
+			return nil, logs, fmt.Errorf("failed to get source template version: %s", err)
+		}
+		if source.Job.FileID == uuid.Nil {
+			return nil, logs, fmt.Errorf("source template version %s has no uploaded files", sourceID)
+		}
+		fileID = source.Job.FileID
+		sourceVars, err := client.TemplateVersionVariables(ctx, sourceID)
+		if err != nil {
+			return nil, logs, fmt.Errorf("failed to get source template version variables: %s", err)
+		}
+		for _, variable := range sourceVars {
+			// The API redacts sensitive values, so they must be set with
+			// tf_vars.
+			if variable.Sensitive {
+				continue
+			}
+			vars = append(vars, codersdk.VariableValue{
+				Name:  variable.Name,
+				Value: variable.Value,
+			})
+		}
+	} else {
+		directory := req.Version.Directory.ValueString()
+		tflog.Info(ctx, "uploading directory")
+		uploadResp, err := uploadDirectory(ctx, client, slog.Make(newTFLogSink(ctx)), directory)
+		if err != nil {
+			return nil, logs, fmt.Errorf("failed to upload directory: %s", err)
+		}
+		tflog.Info(ctx, "successfully uploaded directory")
+		fileID = uploadResp.ID
+		tflog.Info(ctx, "discovering and parsing vars files")
+		varFiles, err := codersdk.DiscoverVarsFiles(directory)
+		if err != nil {
+			return nil, logs, fmt.Errorf("failed to discover vars files: %s", err)
+		}
+		vars, err = codersdk.ParseUserVariableValues(varFiles, "", []string{})
+		if err != nil {
+			return nil, logs, fmt.Errorf("failed to parse user variable values: %s", err)
+		}
+		tflog.Info(ctx, "discovered and parsed vars files", map[string]any{
+			"vars": vars,
+		})
 	}
-	tflog.Info(ctx, "discovered and parsed vars files", map[string]any{
-		"vars": vars,
-	})
 	tfVars, diags := variablesFromSet(ctx, req.Version.TerraformVariables)
 	if diags.HasError() {
 		return nil, logs, fmt.Errorf("failed to extract terraform variables: %s", diags.Errors()[0].Detail())
 	}
 	for _, variable := range tfVars {
+		// tf_vars take precedence over values from vars files or the
+		// source version.
+		vars = slices.DeleteFunc(vars, func(v codersdk.VariableValue) bool {
+			return v.Name == variable.Name.ValueString()
+		})
 		vars = append(vars, codersdk.VariableValue{
 			Name:  variable.Name.ValueString(),
 			Value: variable.Value.ValueString(),
@@ -1349,7 +1710,7 @@ func newVersion(ctx context.Context, client *codersdk.Client, req newVersionRequ
 		Message:            req.Version.Message.ValueString(),
 		StorageMethod:      codersdk.ProvisionerStorageMethodFile,
 		Provisioner:        codersdk.ProvisionerTypeTerraform,
-		FileID:             uploadResp.ID,
+		FileID:             fileID,
 		UserVariableValues: vars,
 		ProvisionerTags:    provTags,
 	}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -206,6 +207,77 @@ func TestTemplateResourceDescriptionSchemaValidation(t *testing.T) {
 	})
 }
 
+func TestVersionsToArchive(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	versions := make([]codersdk.TemplateVersion, 5)
+	for i := range versions {
+		versions[i] = codersdk.TemplateVersion{
+			ID: uuid.New(),
+			// Oldest first, one day apart, the newest created now.
+			CreatedAt: now.Add(-time.Duration(len(versions)-1-i) * 24 * time.Hour),
+		}
+	}
+	ids := func(idx ...int) []uuid.UUID {
+		var out []uuid.UUID
+		for _, i := range idx {
+			out = append(out, versions[i].ID)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name     string
+		keep     []int
+		keepLast int
+		cutoff   time.Time
+		want     []uuid.UUID
+	}{
+		{
+			name: "no rules archives everything",
+			want: ids(0, 1, 2, 3, 4),
+		},
+		{
+			name: "explicitly kept versions survive",
+			keep: []int{0, 3},
+			want: ids(1, 2, 4),
+		},
+		{
+			name:     "keep last",
+			keepLast: 2,
+			want:     ids(0, 1, 2),
+		},
+		{
+			name:   "keep newer than",
+			cutoff: now.Add(-36 * time.Hour),
+			want:   ids(0, 1, 2),
+		},
+		{
+			name:     "rules combine",
+			keep:     []int{0},
+			keepLast: 1,
+			cutoff:   now.Add(-60 * time.Hour),
+			want:     ids(1),
+		},
+		{
+			name:     "keep last exceeds version count",
+			keepLast: 10,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			keep := make(map[uuid.UUID]struct{})
+			for _, id := range ids(tc.keep...) {
+				keep[id] = struct{}{}
+			}
+			require.Equal(t, tc.want, versionsToArchive(versions, keep, tc.keepLast, tc.cutoff))
+		})
+	}
+}
+
 func TestAccTemplateResource(t *testing.T) {
 	t.Parallel()
 	if os.Getenv("TF_ACC") == "" {
@@ -825,6 +897,54 @@ func TestAccTemplateResource(t *testing.T) {
 		})
 	})
 
+	t.Run("VersionRetention", func(t *testing.T) {
+		cfg1 := testAccTemplateResourceConfig{
+			URL:   client.URL.String(),
+			Token: client.SessionToken(),
+			Name:  ptr.Ref("example-template"),
+			Versions: ptr.Ref([]testAccTemplateVersionConfig{
+				{
+					Directory: &exTemplateOne,
+					Active:    ptr.Ref(true),
+				},
+			}),
+			ACL: testAccTemplateACLConfig{
+				null: true,
+			},
+			VersionRetention: &testAccVersionRetentionConfig{
+				KeepLast: ptr.Ref(int64(1)),
+			},
+		}
+
+		// Replacing the only version leaves the previous one unmanaged,
+		// so it should be archived rather than accumulate.
+		cfg2 := cfg1
+		cfg2.Versions = ptr.Ref(slices.Clone(*cfg2.Versions))
+		(*cfg2.Versions)[0].Directory = &exTemplateTwo
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg1.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						testAccCheckNumTemplateVersions(ctx, client, 1),
+						resource.TestCheckResourceAttr("coderd_template.test", "version_retention.keep_last", "1"),
+						resource.TestCheckResourceAttr("coderd_template.test", "version_retention.keep_in_use", "true"),
+					),
+				},
+				{
+					Config: cfg2.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						testAccCheckNumTemplateVersions(ctx, client, 1),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("InvalidMaxPortShareLevel", func(t *testing.T) {
 		cfg1 := testAccTemplateResourceConfig{
 			URL:   client.URL.String(),
@@ -854,6 +974,229 @@ func TestAccTemplateResource(t *testing.T) {
 	})
 }
 
+func TestAccTemplateResourceSourceVersion(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "template_source_version_acc")
+	firstUser, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+
+	// Stand in for a version that was tested in a staging template.
+	staging, _, err := newVersion(ctx, client, newVersionRequest{
+		OrganizationID: firstUser.OrganizationIDs[0],
+		Version: &TemplateVersion{
+			Name:      types.StringValue("staging"),
+			Message:   types.StringValue("Tested in staging"),
+			Directory: types.StringValue("../../integration/template-test/example-template/"),
+			TerraformVariables: mustVariablesToSet([]Variable{
+				{
+					Name:  types.StringValue("name"),
+					Value: types.StringValue("world"),
+				},
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	cfg := testAccTemplateResourceConfig{
+		URL:   client.URL.String(),
+		Token: client.SessionToken(),
+		Name:  ptr.Ref("production"),
+		Versions: ptr.Ref([]testAccTemplateVersionConfig{
+			{
+				Name:            ptr.Ref("promoted"),
+				SourceVersionID: ptr.Ref(staging.ID.String()),
+				Active:          ptr.Ref(true),
+				// The required variable's value is copied from the source.
+			},
+		}),
+		ACL: testAccTemplateACLConfig{
+			null: true,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_template.test", "versions.0.source_version_id", staging.ID.String()),
+					resource.TestCheckNoResourceAttr("coderd_template.test", "versions.0.directory"),
+					resource.TestCheckResourceAttr("coderd_template.test", "versions.0.directory_hash", computeSourceVersionHash(staging.ID)),
+					resource.TestCheckResourceAttrWith("coderd_template.test", "versions.0.id", func(value string) error {
+						promoted, err := client.TemplateVersion(ctx, uuid.MustParse(value))
+						if err != nil {
+							return err
+						}
+						if promoted.Job.FileID != staging.Job.FileID {
+							return fmt.Errorf("expected file ID %s, got %s", staging.Job.FileID, promoted.Job.FileID)
+						}
+						vars, err := client.TemplateVersionVariables(ctx, promoted.ID)
+						if err != nil {
+							return err
+						}
+						if len(vars) != 1 || vars[0].Name != "name" || vars[0].Value != "world" {
+							return fmt.Errorf("expected variable name = world to be copied, got %+v", vars)
+						}
+						return nil
+					}),
+				),
+			},
+			// Re-applying the same source is a no-op.
+			{
+				Config:   cfg.String(t),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccTemplateResourceOnDestroy(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "template_on_destroy_acc", integration.UseLicense)
+
+	exTemplateOne := t.TempDir()
+	err := cp.Copy("../../integration/template-test/example-template", exTemplateOne)
+	require.NoError(t, err)
+
+	// createWorkspace creates a workspace from the template in state so that
+	// it cannot be deleted.
+	createWorkspace := func(name string) resource.CheckResourceAttrWithFunc {
+		return func(value string) error {
+			_, err := client.CreateUserWorkspace(ctx, codersdk.Me, codersdk.CreateWorkspaceRequest{
+				TemplateID: uuid.MustParse(value),
+				Name:       name,
+			})
+			return err
+		}
+	}
+
+	t.Run("FailIfInUseThenDeprecate", func(t *testing.T) {
+		cfg1 := testAccTemplateResourceConfig{
+			URL:   client.URL.String(),
+			Token: client.SessionToken(),
+			Name:  ptr.Ref("deprecated-template"),
+			Versions: ptr.Ref([]testAccTemplateVersionConfig{
+				{
+					Directory: &exTemplateOne,
+					Active:    ptr.Ref(true),
+				},
+			}),
+			ACL: testAccTemplateACLConfig{
+				null: true,
+			},
+		}
+
+		cfg2 := cfg1
+		cfg2.DeprecationMessage = ptr.Ref("Use the new template instead.")
+		cfg2.OnDestroy = ptr.Ref("deprecate")
+		cfg2.MarkWorkspacesDormant = ptr.Ref(true)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg1.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("coderd_template.test", "on_destroy", "fail_if_in_use"),
+						resource.TestCheckResourceAttr("coderd_template.test", "mark_workspaces_dormant", "false"),
+						resource.TestCheckResourceAttrWith("coderd_template.test", "id", createWorkspace("blocking-ws")),
+					),
+				},
+				{
+					Config:      cfg1.String(t),
+					Destroy:     true,
+					ExpectError: regexp.MustCompile(`(?s)Template In Use.*blocking-ws`),
+				},
+				{
+					Config: cfg2.String(t),
+					Check:  resource.TestCheckResourceAttr("coderd_template.test", "on_destroy", "deprecate"),
+				},
+				{
+					Config:  cfg2.String(t),
+					Destroy: true,
+				},
+			},
+			CheckDestroy: func(*terraform.State) error {
+				templates, err := client.Templates(ctx, codersdk.TemplateFilter{
+					ExactName: "deprecated-template",
+				})
+				if err != nil {
+					return err
+				}
+				if len(templates) != 1 {
+					return fmt.Errorf("expected the deprecated template to remain, got %d templates", len(templates))
+				}
+				if !templates[0].Deprecated || templates[0].DeprecationMessage != "Use the new template instead." {
+					return fmt.Errorf("expected template to be deprecated, got %q", templates[0].DeprecationMessage)
+				}
+				workspace, err := client.WorkspaceByOwnerAndName(ctx, codersdk.Me, "blocking-ws", codersdk.WorkspaceOptions{})
+				if err != nil {
+					return err
+				}
+				if workspace.DormantAt == nil {
+					return fmt.Errorf("expected workspace to be dormant")
+				}
+				return nil
+			},
+		})
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		cfg := testAccTemplateResourceConfig{
+			URL:   client.URL.String(),
+			Token: client.SessionToken(),
+			Name:  ptr.Ref("forced-template"),
+			Versions: ptr.Ref([]testAccTemplateVersionConfig{
+				{
+					Directory: &exTemplateOne,
+					Active:    ptr.Ref(true),
+				},
+			}),
+			ACL: testAccTemplateACLConfig{
+				null: true,
+			},
+			OnDestroy: ptr.Ref("force"),
+		}
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg.String(t),
+					Check:  resource.TestCheckResourceAttrWith("coderd_template.test", "id", createWorkspace("forced-ws")),
+				},
+			},
+			CheckDestroy: func(*terraform.State) error {
+				templates, err := client.Templates(ctx, codersdk.TemplateFilter{
+					ExactName: "forced-template",
+				})
+				if err != nil {
+					return err
+				}
+				if len(templates) != 0 {
+					return fmt.Errorf("expected template to be deleted, got %d templates", len(templates))
+				}
+				return nil
+			},
+		})
+	})
+}
+
 func TestAccTemplateResourceAgentsAllowed(t *testing.T) {
 	t.Parallel()
 	if os.Getenv("TF_ACC") == "" {
@@ -1776,8 +2119,43 @@ type testAccTemplateResourceConfig struct {
 	// Versions is a pointer so that a nil value renders `versions = null`
 	// (matching AutostartRequirement above), letting tests exercise
 	// settings-only management of a template (see PLAT-288).
-	Versions *[]testAccTemplateVersionConfig
-	ACL      testAccTemplateACLConfig
+	Versions         *[]testAccTemplateVersionConfig
+	ACL              testAccTemplateACLConfig
+	VersionRetention *testAccVersionRetentionConfig
+
+	OnDestroy             *string
+	MarkWorkspacesDormant *bool
+}
+
+type testAccVersionRetentionConfig struct {
+	KeepLast      *int64
+	KeepNewerThan *string
+	KeepInUse     *bool
+}
+
+func (c testAccTemplateResourceConfig) versionRetentionString(t *testing.T) string {
+	t.Helper()
+	if c.VersionRetention == nil {
+		return "null"
+	}
+	tpl := `{
+		keep_last       = {{orNull .KeepLast}}
+		keep_newer_than = {{orNull .KeepNewerThan}}
+		keep_in_use     = {{orNull .KeepInUse}}
+	}
+	`
+	funcMap := template.FuncMap{
+		"orNull": PrintOrNull,
+	}
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("versionRetention").Funcs(funcMap).Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, *c.VersionRetention)
+	require.NoError(t, err)
+
+	return buf.String()
 }
 
 type testAccTemplateACLConfig struct {
@@ -1867,6 +2245,8 @@ func (c testAccTemplateResourceConfig) versionsString(t *testing.T) string {
 		directory = {{orNull .Directory}}
 		active    = {{orNull .Active}}
 
+		source_version_id = {{orNull .SourceVersionID}}
+
 		tf_vars = [
 			{{- range .TerraformVariables }}
 			{
@@ -1928,6 +2308,11 @@ resource "coderd_template" "test" {
 	acl = ` + c.ACL.String(t) + `
 
 	versions = ` + c.versionsString(t) + `
+
+	version_retention = ` + c.versionRetentionString(t) + `
+
+	on_destroy              = {{orNull .OnDestroy}}
+	mark_workspaces_dormant = {{orNull .MarkWorkspacesDormant}}
 }
 `
 
@@ -1949,6 +2334,7 @@ type testAccTemplateVersionConfig struct {
 	Name               *string
 	Message            *string
 	Directory          *string
+	SourceVersionID    *string
 	Active             *bool
 	TerraformVariables []testAccTemplateKeyValueConfig
 }
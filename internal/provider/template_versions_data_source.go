@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const templateVersionsPageLimit = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TemplateVersionsDataSource{}
+
+func NewTemplateVersionsDataSource() datasource.DataSource {
+	return &TemplateVersionsDataSource{}
+}
+
+// TemplateVersionsDataSource defines the data source implementation.
+type TemplateVersionsDataSource struct {
+	data *CoderdProviderData
+}
+
+// TemplateVersionsDataSourceModel describes the data source data model.
+type TemplateVersionsDataSourceModel struct {
+	TemplateID      UUID       `tfsdk:"template_id"`
+	IncludeArchived types.Bool `tfsdk:"include_archived"`
+
+	Versions []TemplateVersionSummary `tfsdk:"versions"`
+}
+
+type TemplateVersionSummary struct {
+	ID                UUID         `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Message           types.String `tfsdk:"message"`
+	Active            types.Bool   `tfsdk:"active"`
+	Archived          types.Bool   `tfsdk:"archived"`
+	CreatedByID       UUID         `tfsdk:"created_by_id"`
+	CreatedByUsername types.String `tfsdk:"created_by_username"`
+	CreatedAt         types.Int64  `tfsdk:"created_at"`
+	JobStatus         types.String `tfsdk:"job_status"`
+	JobError          types.String `tfsdk:"job_error"`
+	ProvisionerTags   types.Map    `tfsdk:"provisioner_tags"`
+}
+
+func (d *TemplateVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template_versions"
+}
+
+func (d *TemplateVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the versions of an existing template on the Coder deployment, ordered from oldest to newest.",
+
+		Attributes: map[string]schema.Attribute{
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the template to list versions for.",
+				CustomType:          UUIDType,
+				Required:            true,
+			},
+			"include_archived": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include archived versions. Defaults to false.",
+				Optional:            true,
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "Versions of the template.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"message": schema.StringAttribute{
+							Computed: true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is the active version of the template.",
+							Computed:            true,
+						},
+						"archived": schema.BoolAttribute{
+							MarkdownDescription: "Whether the version is archived.",
+							Computed:            true,
+						},
+						"created_by_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user who created the version.",
+							CustomType:          UUIDType,
+							Computed:            true,
+						},
+						"created_by_username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user who created the version.",
+							Computed:            true,
+						},
+						"created_at": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp of when the version was created.",
+							Computed:            true,
+						},
+						"job_status": schema.StringAttribute{
+							MarkdownDescription: "Status of the version's import job, such as `succeeded` or `failed`.",
+							Computed:            true,
+						},
+						"job_error": schema.StringAttribute{
+							MarkdownDescription: "Error reported by the version's import job, if any.",
+							Computed:            true,
+						},
+						"provisioner_tags": schema.MapAttribute{
+							MarkdownDescription: "Provisioner tags the version was created with.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TemplateVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *TemplateVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TemplateVersionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.data.Client
+
+	template, err := client.Template(ctx, data.TemplateID.ValueUUID())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError("Template Not Found", fmt.Sprintf("Unable to find a template with ID %q.", data.TemplateID.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get template, got error: %s", err))
+		return
+	}
+
+	versions, err := listTemplateVersions(ctx, client, template.ID, data.IncludeArchived.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list template versions, got error: %s", err))
+		return
+	}
+
+	summaries := make([]TemplateVersionSummary, 0, len(versions))
+	for _, version := range versions {
+		tags, diags := types.MapValueFrom(ctx, types.StringType, version.Job.Tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		summaries = append(summaries, TemplateVersionSummary{
+			ID:                UUIDValue(version.ID),
+			Name:              types.StringValue(version.Name),
+			Message:           types.StringValue(version.Message),
+			Active:            types.BoolValue(version.ID == template.ActiveVersionID),
+			Archived:          types.BoolValue(version.Archived),
+			CreatedByID:       UUIDValue(version.CreatedBy.ID),
+			CreatedByUsername: types.StringValue(version.CreatedBy.Username),
+			CreatedAt:         types.Int64Value(version.CreatedAt.Unix()),
+			JobStatus:         types.StringValue(string(version.Job.Status)),
+			JobError:          types.StringValue(version.Job.Error),
+			ProvisionerTags:   tags,
+		})
+	}
+	data.Versions = summaries
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listTemplateVersions pages through every version of the template, oldest
+// first.
+func listTemplateVersions(ctx context.Context, client *codersdk.Client, templateID uuid.UUID, includeArchived bool) ([]codersdk.TemplateVersion, error) {
+	req := codersdk.TemplateVersionsByTemplateRequest{
+		TemplateID:      templateID,
+		IncludeArchived: includeArchived,
+		Pagination: codersdk.Pagination{
+			Limit: templateVersionsPageLimit,
+		},
+	}
+	var versions []codersdk.TemplateVersion
+	for {
+		page, err := client.TemplateVersionsByTemplate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, page...)
+		if len(page) < templateVersionsPageLimit {
+			return versions, nil
+		}
+		req.AfterID = page[len(page)-1].ID
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccTemplateVersionsDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "template_versions_data_acc")
+	firstUser, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	orgID := firstUser.OrganizationIDs[0]
+
+	versionReq := newVersionRequest{
+		OrganizationID: orgID,
+		Version: &TemplateVersion{
+			Name:      types.StringValue("first"),
+			Message:   types.StringValue("Initial commit"),
+			Directory: types.StringValue("../../integration/template-test/example-template/"),
+			TerraformVariables: mustVariablesToSet([]Variable{
+				{
+					Name:  types.StringValue("name"),
+					Value: types.StringValue("world"),
+				},
+			}),
+		},
+	}
+	first, _, err := newVersion(ctx, client, versionReq)
+	require.NoError(t, err)
+	tpl, err := client.CreateTemplate(ctx, orgID, codersdk.CreateTemplateRequest{
+		Name:      "versions-template",
+		VersionID: first.ID,
+	})
+	require.NoError(t, err)
+
+	versionReq.TemplateID = &tpl.ID
+	versionReq.Version.Name = types.StringValue("second")
+	versionReq.Version.Message = types.StringValue("Second commit")
+	second, _, err := newVersion(ctx, client, versionReq)
+	require.NoError(t, err)
+	require.NoError(t, markActive(ctx, client, tpl.ID, second.ID))
+
+	versionReq.Version.Name = types.StringValue("third")
+	versionReq.Version.Message = types.StringValue("Archived commit")
+	third, _, err := newVersion(ctx, client, versionReq)
+	require.NoError(t, err)
+	require.NoError(t, client.SetArchiveTemplateVersion(ctx, third.ID, true))
+
+	t.Run("UnarchivedOk", func(t *testing.T) {
+		cfg := testAccTemplateVersionsDataSourceConfig{
+			URL:        client.URL.String(),
+			Token:      client.SessionToken(),
+			TemplateID: tpl.ID.String(),
+		}
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.#", "2"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.id", first.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.name", "first"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.message", "Initial commit"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.active", "false"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.archived", "false"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.created_by_id", firstUser.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.created_by_username", firstUser.Username),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.job_status", string(codersdk.ProvisionerJobSucceeded)),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.0.provisioner_tags.scope", "organization"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.1.id", second.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.1.active", "true"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("IncludeArchivedOk", func(t *testing.T) {
+		cfg := testAccTemplateVersionsDataSourceConfig{
+			URL:             client.URL.String(),
+			Token:           client.SessionToken(),
+			TemplateID:      tpl.ID.String(),
+			IncludeArchived: ptr.Ref(true),
+		}
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.#", "3"),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.2.id", third.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_template_versions.test", "versions.2.archived", "true"),
+					),
+				},
+			},
+		})
+	})
+}
+
+type testAccTemplateVersionsDataSourceConfig struct {
+	URL   string
+	Token string
+
+	TemplateID      string
+	IncludeArchived *bool
+}
+
+func (c testAccTemplateVersionsDataSourceConfig) String(t *testing.T) string {
+	t.Helper()
+	tpl := `
+provider coderd {
+	url   = "{{.URL}}"
+	token = "{{.Token}}"
+}
+
+data "coderd_template_versions" "test" {
+	template_id      = "{{.TemplateID}}"
+	include_archived = {{orNull .IncludeArchived}}
+}`
+
+	funcMap := template.FuncMap{
+		"orNull": PrintOrNull,
+	}
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("templateVersionsDataSource").Funcs(funcMap).Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, c)
+	require.NoError(t, err)
+	return buf.String()
+}
@@ -50,6 +50,7 @@ type UserDataSourceModel struct {
 	OrganizationIDs  types.Set    `tfsdk:"organization_ids"`
 	CreatedAt        types.Int64  `tfsdk:"created_at"` // Unix timestamp
 	LastSeenAt       types.Int64  `tfsdk:"last_seen_at"`
+	GitSSHPublicKey  types.String `tfsdk:"gitssh_public_key"`
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -120,6 +121,10 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Unix timestamp of when the user was last seen.",
 				Computed:            true,
 			},
+			"gitssh_public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key of the user's GitSSH key, which workspaces use to authenticate with Git providers. Null, with a warning, if the provider's token can't read the key.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -217,6 +222,8 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.CreatedAt = types.Int64Value(user.CreatedAt.Unix())
 	data.LastSeenAt = types.Int64Value(user.LastSeenAt.Unix())
 
+	data.GitSSHPublicKey = gitSSHPublicKey(ctx, client, user.ID, &resp.Diagnostics)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
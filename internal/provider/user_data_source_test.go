@@ -16,6 +16,7 @@ import (
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/terraform-provider-coderd/integration"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,6 +83,25 @@ func TestUserDataSourceLookupUserByEmailNotFound(t *testing.T) {
 	require.ErrorIs(t, err, errUserNotFound)
 }
 
+func TestGitSSHPublicKeyForbidden(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(codersdk.Response{Message: "Forbidden."})
+	}))
+	defer srv.Close()
+
+	clientURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	var diags diag.Diagnostics
+	key := gitSSHPublicKey(t.Context(), codersdk.New(clientURL), uuid.New(), &diags)
+	require.True(t, key.IsNull())
+	require.False(t, diags.HasError(), diags.Errors())
+	require.Equal(t, 1, diags.WarningsCount())
+}
+
 func TestAccUserDataSource(t *testing.T) {
 	t.Parallel()
 	if os.Getenv("TF_ACC") == "" {
@@ -118,6 +138,12 @@ func TestAccUserDataSource(t *testing.T) {
 		resource.TestCheckResourceAttr("data.coderd_user.test", "login_type", "password"),
 		resource.TestCheckResourceAttr("data.coderd_user.test", "suspended", "false"),
 		resource.TestCheckResourceAttr("data.coderd_user.test", "is_service_account", "false"),
+		resource.TestCheckResourceAttrWith("data.coderd_user.test", "gitssh_public_key", func(value string) error {
+			if !strings.HasPrefix(value, "ssh-") {
+				return fmt.Errorf("expected an OpenSSH public key, got %q", value)
+			}
+			return nil
+		}),
 	)
 	t.Run("UserByUsernameOk", func(t *testing.T) {
 		cfg := testAccUserDataSourceConfig{
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserExternalAuthDataSource{}
+
+func NewUserExternalAuthDataSource() datasource.DataSource {
+	return &UserExternalAuthDataSource{}
+}
+
+// UserExternalAuthDataSource defines the data source implementation.
+type UserExternalAuthDataSource struct {
+	data *CoderdProviderData
+}
+
+// UserExternalAuthDataSourceModel describes the data source data model.
+type UserExternalAuthDataSourceModel struct {
+	ProviderID types.String `tfsdk:"provider_id"`
+
+	Linked          types.Bool   `tfsdk:"linked"`
+	Authenticated   types.Bool   `tfsdk:"authenticated"`
+	ValidateError   types.String `tfsdk:"validate_error"`
+	HasRefreshToken types.Bool   `tfsdk:"has_refresh_token"`
+	ExpiresAt       types.Int64  `tfsdk:"expires_at"`
+}
+
+func (d *UserExternalAuthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_external_auth"
+}
+
+func (d *UserExternalAuthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Whether the user the provider is authenticated as has linked their account with an external auth provider.
+
+Coder only reveals a user's external auth links to that user, so this reports on the owner of the provider's ` + "`token`" + `, such as a service account that builds workspaces from templates requiring the provider.
+
+Reading fails if the deployment has no provider with the given ID.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"provider_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the external auth provider, as referenced by templates.",
+				Required:            true,
+			},
+			"linked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user has linked their account with the provider.",
+				Computed:            true,
+			},
+			"authenticated": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user's link is usable. False if the link's token has expired, or the provider rejected it.",
+				Computed:            true,
+			},
+			"validate_error": schema.StringAttribute{
+				MarkdownDescription: "Why the provider rejected the user's token. Empty if it didn't.",
+				Computed:            true,
+			},
+			"has_refresh_token": schema.BoolAttribute{
+				MarkdownDescription: "Whether Coder can refresh the user's token when it expires.",
+				Computed:            true,
+			},
+			"expires_at": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp of when the user's token expires. Null if the user hasn't linked the provider, or the token doesn't expire.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserExternalAuthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *UserExternalAuthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserExternalAuthDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	externalAuths, err := d.data.Client.ListExternalAuths(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list external auth providers, got error: %s", err))
+		return
+	}
+
+	providerID := data.ProviderID.ValueString()
+	ids := make([]string, 0, len(externalAuths.Providers))
+	configured := false
+	for _, provider := range externalAuths.Providers {
+		ids = append(ids, provider.ID)
+		if provider.ID == providerID {
+			configured = true
+		}
+	}
+	if !configured {
+		configuredIDs := "none"
+		if len(ids) > 0 {
+			configuredIDs = strings.Join(ids, ", ")
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("provider_id"), "External Auth Provider Not Found",
+			fmt.Sprintf("The deployment has no external auth provider %q. Configured providers: %s.", providerID, configuredIDs))
+		return
+	}
+
+	data.Linked = types.BoolValue(false)
+	data.Authenticated = types.BoolValue(false)
+	data.ValidateError = types.StringValue("")
+	data.HasRefreshToken = types.BoolValue(false)
+	data.ExpiresAt = types.Int64Null()
+	for _, link := range externalAuths.Links {
+		if link.ProviderID != providerID {
+			continue
+		}
+		data.Linked = types.BoolValue(true)
+		data.Authenticated = types.BoolValue(link.Authenticated)
+		data.ValidateError = types.StringValue(link.ValidateError)
+		data.HasRefreshToken = types.BoolValue(link.HasRefreshToken)
+		if !link.Expires.IsZero() {
+			data.ExpiresAt = types.Int64Value(link.Expires.Unix())
+		}
+		break
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserExternalAuthDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "user_external_auth_data_acc", integration.CoderEnv(testAccExternalAuthEnv))
+
+	cfg := func(providerID string) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_user_external_auth" "test" {
+	provider_id = %q
+}
+`, client.URL.String(), client.SessionToken(), providerID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg("github"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_user_external_auth.test", "linked", "false"),
+					resource.TestCheckResourceAttr("data.coderd_user_external_auth.test", "authenticated", "false"),
+					resource.TestCheckNoResourceAttr("data.coderd_user_external_auth.test", "expires_at"),
+				),
+			},
+			{
+				Config:      cfg("gitlab"),
+				ExpectError: regexp.MustCompile(`no external auth provider "gitlab"`),
+			},
+		},
+	})
+}
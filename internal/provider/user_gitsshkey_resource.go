@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserGitSSHKeyResource{}
+var _ resource.ResourceWithImportState = &UserGitSSHKeyResource{}
+
+type UserGitSSHKeyResource struct {
+	*CoderdProviderData
+}
+
+// UserGitSSHKeyResourceModel describes the resource data model.
+type UserGitSSHKeyResourceModel struct {
+	UserID          UUID         `tfsdk:"user_id"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	PublicKey       types.String `tfsdk:"public_key"`
+}
+
+func NewUserGitSSHKeyResource() resource.Resource {
+	return &UserGitSSHKeyResource{}
+}
+
+func (r *UserGitSSHKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_gitsshkey"
+}
+
+func (r *UserGitSSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The GitSSH key of a user on the Coder deployment, which workspaces use to authenticate with Git providers.
+Multiple instances of this resource for a single user will conflict.
+
+Every user has a GitSSH key from the moment they are created, so creating this resource adopts the current key rather than generating a new one. Changing ` + "`rotation_trigger`" + ` regenerates the key, and ` + "`public_key`" + ` is unknown until apply so that resources registering it with a Git provider are updated in the same apply.
+
+~> **Warning**
+` + "`terraform destroy`" + ` only removes this resource from state. The key itself is deleted along with the user.
+`,
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				Required:            true,
+				MarkdownDescription: "The ID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "An arbitrary value that, when changed, regenerates the key. For example, `time_rotating.gitssh.id`.",
+			},
+			"public_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public key, in OpenSSH authorized_keys format.",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged("rotation_trigger"),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserGitSSHKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *UserGitSSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data UserGitSSHKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueUUID()
+	key, err := r.Client.GitSSHKey(ctx, userID.String())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("User with ID %q not found. Marking resource as deleted.", userID.String()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get user GitSSH key, got error: %s", err))
+		return
+	}
+	data.PublicKey = types.StringValue(key.PublicKey)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGitSSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data UserGitSSHKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueUUID()
+	key, err := r.Client.GitSSHKey(ctx, userID.String())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get user GitSSH key, got error: %s", err))
+		return
+	}
+	data.PublicKey = types.StringValue(key.PublicKey)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGitSSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data UserGitSSHKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// public_key is only unknown when rotation_trigger changed.
+	if data.PublicKey.IsUnknown() {
+		userID := data.UserID.ValueUUID()
+		tflog.Info(ctx, "regenerating user GitSSH key", map[string]any{
+			"user_id": userID,
+		})
+		key, err := r.Client.RegenerateGitSSHKey(ctx, userID.String())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to regenerate user GitSSH key, got error: %s", err))
+			return
+		}
+		data.PublicKey = types.StringValue(key.PublicKey)
+		tflog.Info(ctx, "successfully regenerated user GitSSH key")
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGitSSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The key cannot exist without its user, so there is nothing to delete.
+}
+
+func (r *UserGitSSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using user ID
+	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+}
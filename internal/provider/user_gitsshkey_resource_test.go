@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccUserGitSSHKeyResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "user_gitsshkey_acc")
+
+	me, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	original, err := client.GitSSHKey(ctx, me.ID.String())
+	require.NoError(t, err)
+
+	cfg := func(trigger string) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_user_gitsshkey" "test" {
+	user_id          = %q
+	rotation_trigger = %q
+}
+`, client.URL.String(), client.SessionToken(), me.ID.String(), trigger)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Creating the resource adopts the existing key.
+			{
+				Config: cfg("1"),
+				Check:  resource.TestCheckResourceAttr("coderd_user_gitsshkey.test", "public_key", original.PublicKey),
+			},
+			{
+				Config:                               cfg("1"),
+				ResourceName:                         "coderd_user_gitsshkey.test",
+				ImportState:                          true,
+				ImportStateId:                        me.ID.String(),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+				ImportStateVerifyIgnore:              []string{"rotation_trigger"},
+			},
+			// Changing the trigger regenerates the key.
+			{
+				Config: cfg("2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_user_gitsshkey.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("coderd_user_gitsshkey.test", tfjsonpath.New("public_key")),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					key, err := client.GitSSHKey(ctx, me.ID.String())
+					if err != nil {
+						return err
+					}
+					if key.PublicKey == original.PublicKey {
+						return fmt.Errorf("expected the key to be regenerated")
+					}
+					return resource.TestCheckResourceAttr("coderd_user_gitsshkey.test", "public_key", key.PublicKey)(s)
+				},
+			},
+		},
+	})
+}
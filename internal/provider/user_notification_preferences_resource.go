@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserNotificationPreferencesResource{}
+var _ resource.ResourceWithImportState = &UserNotificationPreferencesResource{}
+
+type UserNotificationPreferencesResource struct {
+	*CoderdProviderData
+}
+
+// UserNotificationPreferencesResourceModel describes the resource data model.
+type UserNotificationPreferencesResourceModel struct {
+	UserID              UUID      `tfsdk:"user_id"`
+	DisabledTemplateIDs types.Set `tfsdk:"disabled_template_ids"`
+}
+
+func NewUserNotificationPreferencesResource() resource.Resource {
+	return &UserNotificationPreferencesResource{}
+}
+
+func (r *UserNotificationPreferencesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_notification_preferences"
+}
+
+func (r *UserNotificationPreferencesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The notification preferences of a user on the Coder deployment, typically a service account whose notifications nobody reads.
+Multiple instances of this resource for a single user will conflict.
+
+This resource owns the user's full set of opt-outs: any notification template the user disabled that is not listed in ` + "`disabled_template_ids`" + ` is re-enabled on apply, and ` + "`terraform destroy`" + ` re-enables every listed template.
+`,
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				CustomType:          UUIDType,
+				Required:            true,
+				MarkdownDescription: "The ID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disabled_template_ids": schema.SetAttribute{
+				ElementType:         UUIDType,
+				Required:            true,
+				MarkdownDescription: "IDs of the notification templates the user will not receive notifications from.",
+			},
+		},
+	}
+}
+
+func (r *UserNotificationPreferencesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+func (r *UserNotificationPreferencesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data UserNotificationPreferencesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueUUID()
+	prefs, err := r.Client.GetUserNotificationPreferences(ctx, userID)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("User with ID %q not found. Marking resource as deleted.", userID.String()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get user notification preferences, got error: %s", err))
+		return
+	}
+
+	data.DisabledTemplateIDs = disabledTemplateIDs(prefs)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserNotificationPreferencesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data UserNotificationPreferencesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "creating user notification preferences", map[string]any{
+		"user_id": data.UserID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(r.put(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "successfully created user notification preferences")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserNotificationPreferencesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data UserNotificationPreferencesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updating user notification preferences", map[string]any{
+		"user_id": data.UserID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(r.put(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "successfully updated user notification preferences")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserNotificationPreferencesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data UserNotificationPreferencesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var disabled []UUID
+	resp.Diagnostics.Append(data.DisabledTemplateIDs.ElementsAs(ctx, &disabled, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(disabled) == 0 {
+		return
+	}
+
+	userID := data.UserID.ValueUUID()
+	tflog.Trace(ctx, "deleting user notification preferences", map[string]any{
+		"user_id": userID,
+	})
+
+	templateDisabled := make(map[string]bool, len(disabled))
+	for _, id := range disabled {
+		templateDisabled[id.ValueString()] = false
+	}
+	_, err := r.Client.UpdateUserNotificationPreferences(ctx, userID, codersdk.UpdateUserNotificationPreferences{
+		TemplateDisabledMap: templateDisabled,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			// The user is gone, and their preferences with them.
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset user notification preferences, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "successfully deleted user notification preferences")
+}
+
+func (r *UserNotificationPreferencesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using user ID
+	resource.ImportStatePassthroughID(ctx, path.Root("user_id"), req, resp)
+}
+
+// put makes the user's disabled templates exactly the planned set: planned
+// templates are disabled, and any other currently disabled template is
+// re-enabled. The API only updates the templates it is sent.
+func (r *UserNotificationPreferencesResource) put(ctx context.Context, data UserNotificationPreferencesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planned []UUID
+	diags.Append(data.DisabledTemplateIDs.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	userID := data.UserID.ValueUUID()
+	current, err := r.Client.GetUserNotificationPreferences(ctx, userID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get user notification preferences, got error: %s", err))
+		return diags
+	}
+
+	templateDisabled := make(map[string]bool)
+	for _, pref := range current {
+		if pref.Disabled {
+			templateDisabled[pref.NotificationTemplateID.String()] = false
+		}
+	}
+	for _, id := range planned {
+		templateDisabled[id.ValueString()] = true
+	}
+	if len(templateDisabled) == 0 {
+		return diags
+	}
+
+	_, err = r.Client.UpdateUserNotificationPreferences(ctx, userID, codersdk.UpdateUserNotificationPreferences{
+		TemplateDisabledMap: templateDisabled,
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update user notification preferences, got error: %s", err))
+		return diags
+	}
+	return diags
+}
+
+// disabledTemplateIDs returns the IDs of the templates disabled in prefs.
+func disabledTemplateIDs(prefs []codersdk.NotificationPreference) types.Set {
+	ids := make([]attr.Value, 0, len(prefs))
+	for _, pref := range prefs {
+		if !pref.Disabled {
+			continue
+		}
+		ids = append(ids, UUIDValue(pref.NotificationTemplateID))
+	}
+	return types.SetValueMust(UUIDType, ids)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccUserNotificationPreferencesResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "user_notification_preferences_acc")
+
+	templates, err := client.GetSystemNotificationTemplates(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(templates), 2)
+
+	// A template disabled out of band is re-enabled by the resource.
+	me, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	_, err = client.UpdateUserNotificationPreferences(ctx, me.ID, codersdk.UpdateUserNotificationPreferences{
+		TemplateDisabledMap: map[string]bool{templates[1].ID.String(): true},
+	})
+	require.NoError(t, err)
+
+	cfg := func(ids ...string) string {
+		quoted := make([]string, 0, len(ids))
+		for _, id := range ids {
+			quoted = append(quoted, fmt.Sprintf("%q", id))
+		}
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_user" "me" {
+	username = %q
+}
+
+resource "coderd_user_notification_preferences" "test" {
+	user_id               = data.coderd_user.me.id
+	disabled_template_ids = [%s]
+}
+`, client.URL.String(), client.SessionToken(), me.Username, strings.Join(quoted, ", "))
+	}
+
+	first, second := templates[0].ID.String(), templates[1].ID.String()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(first),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_user_notification_preferences.test", "disabled_template_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("coderd_user_notification_preferences.test", "disabled_template_ids.*", first),
+				),
+			},
+			{
+				Config:                               cfg(first),
+				ResourceName:                         "coderd_user_notification_preferences.test",
+				ImportState:                          true,
+				ImportStateId:                        me.ID.String(),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+			{
+				Config: cfg(first, second),
+				Check:  resource.TestCheckResourceAttr("coderd_user_notification_preferences.test", "disabled_template_ids.#", "2"),
+			},
+			{
+				Config: cfg(),
+				Check:  resource.TestCheckResourceAttr("coderd_user_notification_preferences.test", "disabled_template_ids.#", "0"),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserQuotaDataSource{}
+
+func NewUserQuotaDataSource() datasource.DataSource {
+	return &UserQuotaDataSource{}
+}
+
+// UserQuotaDataSource defines the data source implementation.
+type UserQuotaDataSource struct {
+	data *CoderdProviderData
+}
+
+// UserQuotaDataSourceModel describes the data source data model.
+type UserQuotaDataSourceModel struct {
+	UserID         UUID `tfsdk:"user_id"`
+	OrganizationID UUID `tfsdk:"organization_id"`
+
+	Budget          types.Int64 `tfsdk:"budget"`
+	CreditsConsumed types.Int64 `tfsdk:"credits_consumed"`
+}
+
+func (d *UserQuotaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_quota"
+}
+
+func (d *UserQuotaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The workspace quota of a user within an organization on the Coder deployment.
+
+A user's budget is the sum of the ` + "`quota_allowance`" + ` of every group they belong to in the organization, and the credits they consume are the sum of the ` + "`daily_cost`" + ` of their workspaces' resources. A user can't start a workspace that would take them over budget.
+
+~> **Warning**
+Workspace quotas require an Enterprise or Premium license.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user.",
+				CustomType:          UUIDType,
+				Required:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider default organization ID.",
+				CustomType:          UUIDType,
+				Optional:            true,
+				Computed:            true,
+			},
+			"budget": schema.Int64Attribute{
+				MarkdownDescription: "The number of quota credits the user is allowed.",
+				Computed:            true,
+			},
+			"credits_consumed": schema.Int64Attribute{
+				MarkdownDescription: "The number of quota credits the user's workspaces consume.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserQuotaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *UserQuotaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserQuotaDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.OrganizationID.IsNull() {
+		data.OrganizationID = UUIDValue(d.data.DefaultOrganizationID)
+	}
+
+	quota, err := d.data.Client.WorkspaceQuota(ctx, data.OrganizationID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workspace quota, got error: %s", err))
+		return
+	}
+	data.Budget = types.Int64Value(int64(quota.Budget))
+	data.CreditsConsumed = types.Int64Value(int64(quota.CreditsConsumed))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// workspaceQuotaEntitlement is required to read workspace quotas, which Coder
+// enforces as part of template access control.
+var workspaceQuotaEntitlement = entitlement{
+	Feature: codersdk.FeatureTemplateRBAC,
+	Usage:   "use workspace quotas",
+}
+
+// workspaceQuotaParallelism bounds the concurrent requests of
+// workspaceQuotas.
+const workspaceQuotaParallelism = 8
+
+// workspaceQuotas returns the workspace quota of each user within the
+// organization. It stops at the first error.
+func workspaceQuotas(ctx context.Context, client *codersdk.Client, organizationID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]codersdk.WorkspaceQuota, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		quotas   = make(map[uuid.UUID]codersdk.WorkspaceQuota, len(userIDs))
+		firstErr error
+	)
+	sem := make(chan struct{}, workspaceQuotaParallelism)
+	var wg sync.WaitGroup
+	for _, userID := range userIDs {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			quota, err := client.WorkspaceQuota(ctx, organizationID.String(), userID.String())
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			quotas[userID] = quota
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return quotas, nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceQuotas(t *testing.T) {
+	t.Parallel()
+
+	userIDs := make([]uuid.UUID, 20)
+	for i := range userIDs {
+		userIDs[i] = uuid.New()
+	}
+	forbidden := userIDs[7]
+
+	newServer := func(t *testing.T, fail bool) (*codersdk.Client, *atomic.Int32) {
+		t.Helper()
+		var inFlight, maxInFlight atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if fail && strings.Contains(r.URL.Path, forbidden.String()) {
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(codersdk.Response{Message: "Forbidden."})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(codersdk.WorkspaceQuota{CreditsConsumed: 2, Budget: 10})
+		}))
+		t.Cleanup(srv.Close)
+		clientURL, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+		return codersdk.New(clientURL), &maxInFlight
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		client, maxInFlight := newServer(t, false)
+		quotas, err := workspaceQuotas(t.Context(), client, uuid.New(), userIDs)
+		require.NoError(t, err)
+		require.Len(t, quotas, len(userIDs))
+		require.Equal(t, 2, quotas[userIDs[0]].CreditsConsumed)
+		require.LessOrEqual(t, maxInFlight.Load(), int32(workspaceQuotaParallelism))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+		client, _ := newServer(t, true)
+		_, err := workspaceQuotas(t.Context(), client, uuid.New(), userIDs)
+		require.ErrorContains(t, err, "Forbidden")
+	})
+}
+
+func TestAccUserQuotaDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "user_quota_data_acc", integration.UseLicense)
+	firstUser, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	orgID := firstUser.OrganizationIDs[0]
+
+	user, err := client.CreateUserWithOrgs(ctx, codersdk.CreateUserRequestWithOrgs{
+		Email:           "quota@coder.com",
+		Username:        "quota",
+		UserLoginType:   codersdk.LoginTypeNone,
+		OrganizationIDs: []uuid.UUID{orgID},
+	})
+	require.NoError(t, err)
+	group, err := client.CreateGroup(ctx, orgID, codersdk.CreateGroupRequest{
+		Name:           "quota-group",
+		QuotaAllowance: 7,
+	})
+	require.NoError(t, err)
+	_, err = client.PatchGroup(ctx, group.ID, codersdk.PatchGroupRequest{
+		AddUsers: []string{user.ID.String()},
+	})
+	require.NoError(t, err)
+
+	// The Everyone group contributes to the budget too.
+	want, err := client.WorkspaceQuota(ctx, orgID.String(), user.ID.String())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, want.Budget, 7)
+
+	cfg := fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_user_quota" "test" {
+	user_id = %q
+}
+`, client.URL.String(), client.SessionToken(), user.ID.String())
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_user_quota.test", "organization_id", orgID.String()),
+					resource.TestCheckResourceAttr("data.coderd_user_quota.test", "budget", fmt.Sprint(want.Budget)),
+					resource.TestCheckResourceAttr("data.coderd_user_quota.test", "credits_consumed", "0"),
+				),
+			},
+		},
+	})
+}
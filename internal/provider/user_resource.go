@@ -3,12 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/coder/coder/v2/codersdk"
@@ -29,6 +31,15 @@ import (
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
 var _ resource.ResourceWithValidateConfig = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+var _ resourceWithEntitlements = &UserResource{}
+
+// Values of the `on_destroy` attribute.
+const (
+	userOnDestroyDelete                   = "delete"
+	userOnDestroySuspend                  = "suspend"
+	userOnDestroySuspendAndStopWorkspaces = "suspend_and_stop_workspaces"
+)
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -37,6 +48,9 @@ func NewUserResource() resource.Resource {
 // UserResource defines the resource implementation.
 type UserResource struct {
 	data *CoderdProviderData
+	// skipGitSSHKey leaves gitssh_public_key null instead of looking it up,
+	// for coderd_users, which doesn't expose it.
+	skipGitSSHKey bool
 }
 
 // UserResourceModel describes the resource data model.
@@ -51,6 +65,45 @@ type UserResourceModel struct {
 	Password         types.String `tfsdk:"password"`   // only when login_type is password
 	Suspended        types.Bool   `tfsdk:"suspended"`
 	IsServiceAccount types.Bool   `tfsdk:"is_service_account"`
+
+	QuietHoursSchedule types.String `tfsdk:"quiet_hours_schedule"`
+	Appearance         types.Object `tfsdk:"appearance"`
+	GitSSHPublicKey    types.String `tfsdk:"gitssh_public_key"`
+
+	OnDestroy           types.String `tfsdk:"on_destroy"`
+	TransferTemplatesTo UUID         `tfsdk:"transfer_templates_to"`
+}
+
+type UserAppearance struct {
+	ThemePreference types.String `tfsdk:"theme_preference"`
+	TerminalFont    types.String `tfsdk:"terminal_font"`
+}
+
+var userAppearanceType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"theme_preference": types.StringType,
+		"terminal_font":    types.StringType,
+	},
+}
+
+// transferTemplatesEntitlement is required to transfer templates, which
+// edits their ACLs.
+var transferTemplatesEntitlement = entitlement{
+	Feature:   codersdk.FeatureTemplateRBAC,
+	Attribute: path.Root("transfer_templates_to"),
+	Usage:     "use template access control, so you cannot set transfer_templates_to",
+}
+
+// Entitlements implements resourceWithEntitlements.
+func (r *UserResource) Entitlements() []entitlement {
+	return []entitlement{
+		{
+			Feature:   codersdk.FeatureAdvancedTemplateScheduling,
+			Attribute: path.Root("quiet_hours_schedule"),
+			Usage:     "use advanced template scheduling, so you cannot set quiet_hours_schedule",
+		},
+		transferTemplatesEntitlement,
+	}
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -95,17 +148,12 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"roles": schema.SetAttribute{
-				MarkdownDescription: "Roles assigned to the user. Valid roles are `owner`, `template-admin`, `user-admin`, and `auditor`. If `null`, roles will not be managed by Terraform. This attribute must be null if the user is an OIDC user and role sync is configured",
+				MarkdownDescription: "Roles assigned to the user. Any site role the provider's token can assign is valid, including custom roles; the built-in roles are `owner`, `template-admin`, `user-admin`, and `auditor`. Roles are checked against the deployment at plan time. If `null`, roles will not be managed by Terraform. This attribute must be null if the user is an OIDC user and role sync is configured",
 				Optional:            true,
 				ElementType:         types.StringType,
-				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(
-						stringvalidator.OneOf("owner", "template-admin", "user-admin", "auditor"),
-					),
-				},
 			},
 			"login_type": schema.StringAttribute{
-				MarkdownDescription: "Type of login for the user. Valid types are `none`, `password`, `github`, and `oidc`.",
+				MarkdownDescription: "Type of login for the user. Valid types are `none`, `password`, `github`, and `oidc`. A warning is emitted at plan time if the login type's auth method is not enabled on the deployment.",
 				Computed:            true,
 				Optional:            true,
 				Validators: []validator.String{
@@ -136,6 +184,59 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					boolplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
+			"gitssh_public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key of the user's GitSSH key, which workspaces use to authenticate with Git providers. Null, with a warning, if the provider's token can't read the key. To rotate the key and use the new one in the same apply, use `coderd_user_gitsshkey` instead.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"quiet_hours_schedule": schema.StringAttribute{
+				MarkdownDescription: "The user's quiet hours schedule, as a daily cron expression prefixed with its timezone, such as `CRON_TZ=Europe/London 0 2 * * *`. Templates with an `auto_stop_requirement` stop the user's workspaces during their quiet hours. If `null`, the schedule will not be managed by Terraform. Requires an Enterprise or Premium license.",
+				Optional:            true,
+				Validators: []validator.String{
+					codersdkvalidator.QuietHoursSchedule(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What to do with the user when the resource is destroyed. `delete` deletes the user, which fails while they own workspaces. `suspend` suspends the user instead and removes them from the Terraform state, retaining the account and its audit history; recreating the resource then fails until the user is imported. `suspend_and_stop_workspaces` also stops the user's running workspaces. A user that would be suspended can't be replaced, so changing `email`, `login_type` or `is_service_account` fails at plan time until `delete` has been applied. Defaults to `delete`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(userOnDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(userOnDestroyDelete, userOnDestroySuspend, userOnDestroySuspendAndStopWorkspaces),
+				},
+			},
+			"transfer_templates_to": schema.StringAttribute{
+				CustomType:          UUIDType,
+				MarkdownDescription: "(Enterprise) The ID of a user to hand the user's templates to when the resource is destroyed. The successor is granted the `admin` role on every template the user created or administers, and the user is removed from those templates' ACLs. Templates whose ACL is managed by a `coderd_template` will show drift.",
+				Optional:            true,
+			},
+			"appearance": schema.SingleNestedAttribute{
+				MarkdownDescription: "The user's dashboard appearance preferences. If `null`, appearance preferences will not be managed by Terraform.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"theme_preference": schema.StringAttribute{
+						MarkdownDescription: "The dashboard theme. Valid themes are `auto`, `dark`, and `light`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("auto", "dark", "light"),
+						},
+					},
+					"terminal_font": schema.StringAttribute{
+						MarkdownDescription: "The font used by the web terminal. Valid fonts are `ibm-plex-mono`, `fira-code`, `source-code-pro`, and `jetbrains-mono`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(
+								string(codersdk.TerminalFontIBMPlexMono),
+								string(codersdk.TerminalFontFiraCode),
+								string(codersdk.TerminalFontSourceCodePro),
+								string(codersdk.TerminalFontJetBrainsMono),
+							),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -198,6 +299,92 @@ func (r *UserResource) ValidateConfig(ctx context.Context, req resource.Validate
 	}
 }
 
+// ModifyPlan checks the license, and roles and login_type against the
+// deployment. Only roles and login types that change are checked, so a role or
+// auth method later removed from the deployment doesn't block unrelated
+// updates. It also rejects replacing a user that on_destroy would suspend.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to validate.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.data == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.data.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+
+	var plan UserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *UserResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &UserResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if setFullyKnown(plan.Roles) {
+		var planned, prior []string
+		resp.Diagnostics.Append(plan.Roles.ElementsAs(ctx, &planned, false)...)
+		if state != nil && setFullyKnown(state.Roles) {
+			resp.Diagnostics.Append(state.Roles.ElementsAs(ctx, &prior, false)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		added := slices.DeleteFunc(planned, func(role string) bool {
+			return slices.Contains(prior, role)
+		})
+		resp.Diagnostics.Append(r.data.validateSiteRoles(ctx, path.Root("roles"), added)...)
+	}
+
+	if !plan.LoginType.IsUnknown() && (state == nil || !plan.LoginType.Equal(state.LoginType)) {
+		resp.Diagnostics.Append(r.data.validateLoginType(ctx, path.Root("login_type"), plan.LoginType.ValueString())...)
+	}
+
+	// A replacement destroys the old user with the on_destroy behavior in
+	// state. Suspending them keeps their username taken, so creating the new
+	// user would fail.
+	if state != nil && state.OnDestroy.ValueString() != userOnDestroyDelete && !state.OnDestroy.IsNull() {
+		var config UserResourceModel
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if replaced, ok := userReplacedBy(&config, state, &plan); ok {
+			resp.Diagnostics.AddAttributeError(replaced, "Replacement Not Supported",
+				fmt.Sprintf("Changing %s replaces the user, but on_destroy is %q, so the old user would be suspended instead of deleted and their username would stay taken. "+
+					"Set on_destroy to \"delete\" and apply that first, or delete the user out of band.", replaced, state.OnDestroy.ValueString()))
+		}
+	}
+}
+
+// userReplacedBy returns the path of an attribute whose planned change
+// replaces the user, or false if the plan doesn't replace them. It mirrors the
+// RequiresReplaceIfConfigured plan modifiers, which only replace the user for
+// a configured value.
+func userReplacedBy(config, state, plan *UserResourceModel) (path.Path, bool) {
+	for _, a := range []struct {
+		path                       path.Path
+		configured, planned, prior attr.Value
+	}{
+		{path.Root("email"), config.Email, plan.Email, state.Email},
+		{path.Root("login_type"), config.LoginType, plan.LoginType, state.LoginType},
+		{path.Root("is_service_account"), config.IsServiceAccount, plan.IsServiceAccount, state.IsServiceAccount},
+	} {
+		if a.configured.IsNull() || a.planned.IsUnknown() || a.planned.Equal(a.prior) {
+			continue
+		}
+		return a.path, true
+	}
+	return path.Empty(), false
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data UserResourceModel
 
@@ -207,27 +394,126 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.create(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Imported resources have no destroy behavior in state yet.
+	if data.OnDestroy.IsNull() {
+		data.OnDestroy = types.StringValue(userOnDestroyDelete)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deleteUser(ctx, &data)...)
+}
+
+// Req.ID can be either a UUID or a username.
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	_, err := uuid.Parse(req.ID)
+	if err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	client := r.data.Client
+	user, err := client.User(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Invalid import ID format, expected a single UUID or a valid username")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), user.ID.String())...)
+}
+
+// create creates the user described by data and fills in its computed
+// attributes.
+func (r *UserResource) create(ctx context.Context, data *UserResourceModel) (diags diag.Diagnostics) {
 	client := r.data.Client
 
 	me, err := client.User(ctx, codersdk.Me)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return diags
 	}
 	if len(me.OrganizationIDs) < 1 {
-		resp.Diagnostics.AddError("Client Error", "User is not associated with any organizations")
-		return
+		diags.AddError("Client Error", "User is not associated with any organizations")
+		return diags
 	}
 
 	tflog.Info(ctx, "creating user")
 	loginType := codersdk.LoginType(data.LoginType.ValueString())
 	if loginType == codersdk.LoginTypePassword && data.Password.IsNull() {
-		resp.Diagnostics.AddError("Data Error", "Password is required when login_type is 'password'")
-		return
+		diags.AddError("Data Error", "Password is required when login_type is 'password'")
+		return diags
 	}
 	if loginType != codersdk.LoginTypePassword && !data.Password.IsNull() {
-		resp.Diagnostics.AddError("Data Error", "Password is only allowed when login_type is 'password'")
-		return
+		diags.AddError("Data Error", "Password is only allowed when login_type is 'password'")
+		return diags
 	}
 	user, err := client.CreateUserWithOrgs(ctx, codersdk.CreateUserRequestWithOrgs{
 		Email:           data.Email.ValueString(),
@@ -239,8 +525,8 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		ServiceAccount:  data.IsServiceAccount.ValueBool(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		return diags
 	}
 	data.Email = types.StringValue(user.Email)
 	data.IsServiceAccount = types.BoolValue(user.IsServiceAccount)
@@ -259,19 +545,19 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Name:     name.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update newly created user profile, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update newly created user profile, got error: %s", err))
+		return diags
 	}
 	tflog.Info(ctx, "successfully updated user profile")
 	data.Name = types.StringValue(user.Name)
 
 	if !data.Roles.IsNull() {
 		var roles []string
-		resp.Diagnostics.Append(
+		diags.Append(
 			data.Roles.ElementsAs(ctx, &roles, false)...,
 		)
-		if resp.Diagnostics.HasError() {
-			return
+		if diags.HasError() {
+			return diags
 		}
 		tflog.Info(ctx, "updating user roles", map[string]any{
 			"new_roles": roles,
@@ -280,8 +566,8 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 			Roles: roles,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update newly created user roles, got error: %s", err))
-			return
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update newly created user roles, got error: %s", err))
+			return diags
 		}
 		tflog.Info(ctx, "successfully updated user roles")
 	}
@@ -290,35 +576,35 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		_, err = client.UpdateUserStatus(ctx, data.ID.ValueString(), codersdk.UserStatus("suspended"))
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user status, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update user status, got error: %s", err))
+		return diags
 	}
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
 
-func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data UserResourceModel
-
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	diags.Append(r.updatePreferences(ctx, user.ID, data)...)
+	if diags.HasError() {
+		return diags
+	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if !r.skipGitSSHKey {
+		data.GitSSHPublicKey = gitSSHPublicKey(ctx, client, user.ID, &diags)
 	}
+	return diags
+}
 
+// read refreshes data from the deployment. It reports false, with a warning,
+// if the user no longer exists.
+func (r *UserResource) read(ctx context.Context, data *UserResourceModel) (found bool, diags diag.Diagnostics) {
 	client := r.data.Client
 
 	// Lookup by ID to handle imports
 	user, err := client.User(ctx, data.ID.ValueString())
 	if err != nil {
 		if isNotFound(err) {
-			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf("User with ID %q not found. Marking resource as deleted.", data.ID.ValueString()))
-			resp.State.RemoveResource(ctx)
-			return
+			diags.AddWarning("Client Warning", fmt.Sprintf("User with ID %q not found. Marking resource as deleted.", data.ID.ValueString()))
+			return false, diags
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user by ID, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user by ID, got error: %s", err))
+		return true, diags
 	}
 	data.Email = types.StringValue(user.Email)
 	data.Name = types.StringValue(user.Name)
@@ -342,52 +628,61 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	userByName, err := client.User(ctx, data.Username.ValueString())
 	if err != nil {
 		if isNotFound(err) {
-			resp.Diagnostics.AddWarning("Client Warning", fmt.Sprintf(
+			diags.AddWarning("Client Warning", fmt.Sprintf(
 				"User with username %q not found. Marking resource as deleted.",
 				data.Username.ValueString()))
-			resp.State.RemoveResource(ctx)
-			return
+			return false, diags
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user by username, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user by username, got error: %s", err))
+		return true, diags
 	}
 	if userByName.ID != data.ID.ValueUUID() {
-		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf(
+		diags.AddWarning("Client Error", fmt.Sprintf(
 			"The username %q has been reassigned to a new user not managed by this Terraform resource. Marking resource as deleted.",
 			user.Username))
-		resp.State.RemoveResource(ctx)
-		return
+		return false, diags
 	}
 
 	if len(user.OrganizationIDs) < 1 {
-		resp.Diagnostics.AddError("Client Error", "User is not associated with any organizations")
-		return
+		diags.AddError("Client Error", "User is not associated with any organizations")
+		return true, diags
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data UserResourceModel
+	diags.Append(r.readPreferences(ctx, user.ID, data)...)
+	if diags.HasError() {
+		return true, diags
+	}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if !r.skipGitSSHKey {
+		data.GitSSHPublicKey = gitSSHPublicKey(ctx, client, user.ID, &diags)
+	}
+	return true, diags
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+// gitSSHPublicKey returns the public key of a user's GitSSH key. Tokens that
+// can read users can't always read other users' keys, so a failed lookup only
+// warns and returns null rather than failing the read.
+func gitSSHPublicKey(ctx context.Context, client *codersdk.Client, userID uuid.UUID, diags *diag.Diagnostics) types.String {
+	key, err := client.GitSSHKey(ctx, userID.String())
+	if err != nil {
+		diags.AddWarning("Client Warning", fmt.Sprintf("Unable to get the GitSSH key of user %q, so `gitssh_public_key` is null: %s", userID, err))
+		return types.StringNull()
 	}
+	return types.StringValue(key.PublicKey)
+}
 
+// update applies data to the existing user it identifies.
+func (r *UserResource) update(ctx context.Context, data *UserResourceModel) (diags diag.Diagnostics) {
 	client := r.data.Client
 
 	user, err := client.User(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return diags
 	}
 	if len(user.OrganizationIDs) < 1 {
-		resp.Diagnostics.AddError("Client Error", "User is not associated with any organizations")
-		return
+		diags.AddError("Client Error", "User is not associated with any organizations")
+		return diags
 	}
 
 	name := data.Username
@@ -403,19 +698,19 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Name:     name.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user profile, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update user profile, got error: %s", err))
+		return diags
 	}
 	data.Name = name
 	tflog.Info(ctx, "successfully updated user profile")
 
 	if !data.Roles.IsNull() {
 		var roles []string
-		resp.Diagnostics.Append(
+		diags.Append(
 			data.Roles.ElementsAs(ctx, &roles, false)...,
 		)
-		if resp.Diagnostics.HasError() {
-			return
+		if diags.HasError() {
+			return diags
 		}
 		tflog.Info(ctx, "updating user roles", map[string]any{
 			"new_roles": roles,
@@ -424,8 +719,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			Roles: roles,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user roles, got error: %s", err))
-			return
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update user roles, got error: %s", err))
+			return diags
 		}
 		tflog.Info(ctx, "successfully updated user roles")
 	}
@@ -436,8 +731,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			Password: data.Password.ValueString(),
 		})
 		if err != nil && !strings.Contains(err.Error(), "New password cannot match old password.") {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update password, got error: %s", err))
-			return
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update password, got error: %s", err))
+			return diags
 		}
 		tflog.Info(ctx, "successfully updated password")
 	}
@@ -450,47 +745,212 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		_, statusErr = client.UpdateUserStatus(ctx, data.ID.ValueString(), codersdk.UserStatus("active"))
 	}
 	if statusErr != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user status, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update user status, got error: %s", err))
+		return diags
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	diags.Append(r.updatePreferences(ctx, user.ID, data)...)
+	return diags
 }
 
-func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data UserResourceModel
-
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
+// deleteUser deletes or suspends the user data identifies, according to
+// its `on_destroy` behavior.
+func (r *UserResource) deleteUser(ctx context.Context, data *UserResourceModel) (diags diag.Diagnostics) {
+	client := r.data.Client
+	userID := data.ID.ValueUUID()
+
+	if !data.TransferTemplatesTo.IsNull() {
+		// Checked before the user is deleted, so their templates aren't
+		// left without an admin if the license has since lapsed.
+		diags.Append(r.data.requireEntitlement(transferTemplatesEntitlement)...)
+		if diags.HasError() {
+			return diags
+		}
+		diags.Append(r.transferTemplates(ctx, userID, data.TransferTemplatesTo.ValueUUID())...)
+		if diags.HasError() {
+			return diags
+		}
 	}
 
-	client := r.data.Client
+	switch data.OnDestroy.ValueString() {
+	case userOnDestroySuspend, userOnDestroySuspendAndStopWorkspaces:
+		tflog.Info(ctx, "suspending user instead of deleting")
+		_, err := client.UpdateUserStatus(ctx, userID.String(), codersdk.UserStatusSuspended)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to suspend user, got error: %s", err))
+			return diags
+		}
+		tflog.Info(ctx, "successfully suspended user")
+		if data.OnDestroy.ValueString() == userOnDestroySuspendAndStopWorkspaces {
+			diags.Append(r.stopWorkspaces(ctx, data.Username.ValueString())...)
+		}
+		return diags
+	}
 
 	tflog.Info(ctx, "deleting user")
-	err := client.DeleteUser(ctx, data.ID.ValueUUID())
+	err := client.DeleteUser(ctx, userID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+		return diags
 	}
 	tflog.Info(ctx, "successfully deleted user")
+	return diags
 }
 
-// Req.ID can be either a UUID or a username.
-func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	_, err := uuid.Parse(req.ID)
-	if err == nil {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-		return
+// transferTemplates grants successorID the admin role on every template
+// userID created or administers, and removes userID from those templates'
+// ACLs.
+func (r *UserResource) transferTemplates(ctx context.Context, userID, successorID uuid.UUID) (diags diag.Diagnostics) {
+	client := r.data.Client
+
+	templates, err := client.Templates(ctx, codersdk.TemplateFilter{})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list templates, got error: %s", err))
+		return diags
+	}
+	for _, template := range templates {
+		acl, err := client.TemplateACL(ctx, template.ID)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to get ACL of template %q, got error: %s", template.Name, err))
+			return diags
+		}
+		owned := template.CreatedByID == userID
+		inACL := false
+		for _, user := range acl.Users {
+			if user.ID == userID {
+				inACL = true
+				owned = owned || user.Role == codersdk.TemplateRoleAdmin
+			}
+		}
+		if !owned {
+			continue
+		}
+
+		tflog.Info(ctx, "transferring template", map[string]any{
+			"template_id":  template.ID.String(),
+			"successor_id": successorID.String(),
+		})
+		userPerms := map[string]codersdk.TemplateRole{
+			successorID.String(): codersdk.TemplateRoleAdmin,
+		}
+		if inACL {
+			userPerms[userID.String()] = ""
+		}
+		err = client.UpdateTemplateACL(ctx, template.ID, codersdk.UpdateTemplateACL{
+			UserPerms: userPerms,
+		})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to transfer template %q, got error: %s", template.Name, err))
+			return diags
+		}
 	}
+	return diags
+}
+
+// stopWorkspaces stops every started workspace owned by username, waiting
+// for each stop build to finish.
+func (r *UserResource) stopWorkspaces(ctx context.Context, username string) (diags diag.Diagnostics) {
 	client := r.data.Client
-	user, err := client.User(ctx, req.ID)
+
+	workspaces, err := listWorkspaces(ctx, client, codersdk.WorkspaceFilter{
+		Owner: username,
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Invalid import ID format, expected a single UUID or a valid username")
-		return
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list the user's workspaces, got error: %s", err))
+		return diags
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), user.ID.String())...)
+	for _, workspace := range workspaces {
+		if workspace.LatestBuild.Transition != codersdk.WorkspaceTransitionStart {
+			continue
+		}
+		tflog.Info(ctx, "stopping workspace", map[string]any{
+			"workspace_id": workspace.ID.String(),
+		})
+		err := buildWorkspace(ctx, client, workspace.ID, codersdk.WorkspaceTransitionStop)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to stop workspace %s: %s", workspaceDisplayName(workspace), err))
+			return diags
+		}
+	}
+	return diags
+}
+
+// updatePreferences applies the managed quiet hours schedule and appearance
+// preferences, and stores the values the deployment reports back.
+func (r *UserResource) updatePreferences(ctx context.Context, userID uuid.UUID, data *UserResourceModel) (diags diag.Diagnostics) {
+	client := r.data.Client
+
+	if !data.QuietHoursSchedule.IsNull() {
+		tflog.Info(ctx, "updating user quiet hours schedule", map[string]any{
+			"schedule": data.QuietHoursSchedule.ValueString(),
+		})
+		schedule, err := client.UpdateUserQuietHoursSchedule(ctx, userID.String(), codersdk.UpdateUserQuietHoursScheduleRequest{
+			Schedule: data.QuietHoursSchedule.ValueString(),
+		})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update user quiet hours schedule, got error: %s", err))
+			return diags
+		}
+		data.QuietHoursSchedule = types.StringValue(schedule.RawSchedule)
+		tflog.Info(ctx, "successfully updated user quiet hours schedule")
+	}
+
+	if !data.Appearance.IsNull() {
+		var appearance UserAppearance
+		diags.Append(data.Appearance.As(ctx, &appearance, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+		tflog.Info(ctx, "updating user appearance settings", map[string]any{
+			"theme_preference": appearance.ThemePreference.ValueString(),
+			"terminal_font":    appearance.TerminalFont.ValueString(),
+		})
+		settings, err := client.UpdateUserAppearanceSettings(ctx, userID.String(), codersdk.UpdateUserAppearanceSettingsRequest{
+			ThemePreference: appearance.ThemePreference.ValueString(),
+			TerminalFont:    codersdk.TerminalFontName(appearance.TerminalFont.ValueString()),
+		})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update user appearance settings, got error: %s", err))
+			return diags
+		}
+		value, valueDiags := userAppearanceValue(ctx, settings)
+		diags.Append(valueDiags...)
+		data.Appearance = value
+		tflog.Info(ctx, "successfully updated user appearance settings")
+	}
+	return diags
+}
+
+// readPreferences refreshes the quiet hours schedule and appearance
+// preferences, if they are managed.
+func (r *UserResource) readPreferences(ctx context.Context, userID uuid.UUID, data *UserResourceModel) (diags diag.Diagnostics) {
+	client := r.data.Client
+
+	if !data.QuietHoursSchedule.IsNull() {
+		schedule, err := client.UserQuietHoursSchedule(ctx, userID.String())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to get user quiet hours schedule, got error: %s", err))
+			return diags
+		}
+		data.QuietHoursSchedule = types.StringValue(schedule.RawSchedule)
+	}
+
+	if !data.Appearance.IsNull() {
+		settings, err := client.GetUserAppearanceSettings(ctx, userID.String())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to get user appearance settings, got error: %s", err))
+			return diags
+		}
+		value, valueDiags := userAppearanceValue(ctx, settings)
+		diags.Append(valueDiags...)
+		data.Appearance = value
+	}
+	return diags
+}
+
+func userAppearanceValue(ctx context.Context, settings codersdk.UserAppearanceSettings) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, userAppearanceType.AttrTypes, UserAppearance{
+		ThemePreference: types.StringValue(settings.ThemePreference),
+		TerminalFont:    types.StringValue(string(settings.TerminalFont)),
+	})
 }
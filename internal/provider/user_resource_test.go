@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -8,7 +9,10 @@ import (
 	"text/template"
 
 	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
@@ -70,6 +74,7 @@ func TestAccUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr("coderd_user.test", "login_type", "password"),
 					resource.TestCheckResourceAttr("coderd_user.test", "password", "SomeSecurePassword!"),
 					resource.TestCheckResourceAttr("coderd_user.test", "suspended", "false"),
+					resource.TestCheckResourceAttrSet("coderd_user.test", "gitssh_public_key"),
 				),
 			},
 			// Import by ID
@@ -287,9 +292,181 @@ func TestAccUserResourceServiceAccount(t *testing.T) {
 	})
 }
 
+func TestAccUserResourcePreferences(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	// Quiet hours schedules require advanced template scheduling.
+	client := integration.StartCoder(ctx, t, "user_preferences_acc", integration.UseLicense)
+
+	cfg1 := testAccUserResourceConfig{
+		URL:                client.URL.String(),
+		Token:              client.SessionToken(),
+		Username:           ptr.Ref("quiet"),
+		Email:              ptr.Ref("quiet@coder.com"),
+		QuietHoursSchedule: ptr.Ref("CRON_TZ=Europe/London 0 2 * * *"),
+		ThemePreference:    ptr.Ref("dark"),
+		TerminalFont:       ptr.Ref("fira-code"),
+	}
+
+	cfg2 := cfg1
+	cfg2.QuietHoursSchedule = ptr.Ref("CRON_TZ=America/Chicago 30 23 * * *")
+	cfg2.ThemePreference = ptr.Ref("light")
+	cfg2.TerminalFont = ptr.Ref("jetbrains-mono")
+
+	cfgInvalid := cfg1
+	cfgInvalid.QuietHoursSchedule = ptr.Ref("0 2 * * 1")
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfgInvalid.String(t),
+				ExpectError: regexp.MustCompile("valid daily cron schedule"),
+			},
+			{
+				Config: cfg1.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_user.test", "quiet_hours_schedule", "CRON_TZ=Europe/London 0 2 * * *"),
+					resource.TestCheckResourceAttr("coderd_user.test", "appearance.theme_preference", "dark"),
+					resource.TestCheckResourceAttr("coderd_user.test", "appearance.terminal_font", "fira-code"),
+				),
+			},
+			{
+				Config: cfg2.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_user.test", "quiet_hours_schedule", "CRON_TZ=America/Chicago 30 23 * * *"),
+					resource.TestCheckResourceAttr("coderd_user.test", "appearance.theme_preference", "light"),
+					resource.TestCheckResourceAttr("coderd_user.test", "appearance.terminal_font", "jetbrains-mono"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceOnDestroy(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	// Template ACLs require a license.
+	client := integration.StartCoder(ctx, t, "user_on_destroy_acc", integration.UseLicense)
+	firstUser, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	orgID := firstUser.OrganizationIDs[0]
+
+	successor, err := client.CreateUserWithOrgs(ctx, codersdk.CreateUserRequestWithOrgs{
+		Email:           "successor@coder.com",
+		Username:        "successor",
+		UserLoginType:   codersdk.LoginTypeNone,
+		OrganizationIDs: []uuid.UUID{orgID},
+	})
+	require.NoError(t, err)
+
+	version, _, err := newVersion(ctx, client, newVersionRequest{
+		OrganizationID: orgID,
+		Version: &TemplateVersion{
+			Name:      types.StringValue("main"),
+			Message:   types.StringValue("Initial commit"),
+			Directory: types.StringValue("../../integration/template-test/example-template/"),
+			TerraformVariables: mustVariablesToSet([]Variable{
+				{
+					Name:  types.StringValue("name"),
+					Value: types.StringValue("world"),
+				},
+			}),
+		},
+	})
+	require.NoError(t, err)
+	tpl, err := client.CreateTemplate(ctx, orgID, codersdk.CreateTemplateRequest{
+		Name:      "departing-template",
+		VersionID: version.ID,
+	})
+	require.NoError(t, err)
+
+	cfg := testAccUserResourceConfig{
+		URL:                 client.URL.String(),
+		Token:               client.SessionToken(),
+		Username:            ptr.Ref("departing"),
+		Email:               ptr.Ref("departing@coder.com"),
+		OnDestroy:           ptr.Ref("suspend_and_stop_workspaces"),
+		TransferTemplatesTo: ptr.Ref(successor.ID.String()),
+	}
+
+	cfgNewEmail := cfg
+	cfgNewEmail.Email = ptr.Ref("departed@coder.com")
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			user, err := client.User(ctx, "departing")
+			if err != nil {
+				return fmt.Errorf("expected the user to be retained: %w", err)
+			}
+			if user.Status != codersdk.UserStatusSuspended {
+				return fmt.Errorf("expected the user to be suspended, got %q", user.Status)
+			}
+			acl, err := client.TemplateACL(ctx, tpl.ID)
+			if err != nil {
+				return err
+			}
+			roles := map[uuid.UUID]codersdk.TemplateRole{}
+			for _, u := range acl.Users {
+				roles[u.ID] = u.Role
+			}
+			if roles[successor.ID] != codersdk.TemplateRoleAdmin {
+				return fmt.Errorf("expected the successor to administer the template, got %q", roles[successor.ID])
+			}
+			if _, ok := roles[user.ID]; ok {
+				return fmt.Errorf("expected the user to be removed from the template ACL")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: cfg.String(t),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_user.test", "on_destroy", "suspend_and_stop_workspaces"),
+					func(*terraform.State) error {
+						user, err := client.User(ctx, "departing")
+						if err != nil {
+							return err
+						}
+						return client.UpdateTemplateACL(ctx, tpl.ID, codersdk.UpdateTemplateACL{
+							UserPerms: map[string]codersdk.TemplateRole{
+								user.ID.String(): codersdk.TemplateRoleAdmin,
+							},
+						})
+					},
+				),
+			},
+			// A suspended user's username stays taken, so they can't be replaced.
+			{
+				Config:      cfgNewEmail.String(t),
+				ExpectError: regexp.MustCompile(`Replacement Not Supported`),
+			},
+			// Imported users are deleted on destroy unless configured otherwise.
+			{
+				ResourceName:            "coderd_user.test",
+				ImportState:             true,
+				ImportStateId:           "departing",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy", "transfer_templates_to"},
+			},
+		},
+	})
+}
+
 // TestAccUserResourceValidateConfig exercises the plan-time validation around
-// the now-optional email and the service-account constraints. These are config
-// errors caught before any API call, so an unlicensed deployment is sufficient.
+// the now-optional email, the service-account constraints, and roles. An
+// unlicensed deployment is sufficient.
 func TestAccUserResourceValidateConfig(t *testing.T) {
 	t.Parallel()
 	if os.Getenv("TF_ACC") == "" {
@@ -329,6 +506,12 @@ func TestAccUserResourceValidateConfig(t *testing.T) {
 	saWithLoginType.IsServiceAccount = ptr.Ref(true)
 	saWithLoginType.LoginType = ptr.Ref("password")
 
+	// Roles are checked against the deployment's assignable roles at plan time.
+	unknownRole := base
+	unknownRole.Username = ptr.Ref("unknown-role")
+	unknownRole.Email = ptr.Ref("unknown-role@coder.com")
+	unknownRole.Roles = ptr.Ref([]string{"not-a-role"})
+
 	resource.Test(t, resource.TestCase{
 		IsUnitTest:               true,
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -354,6 +537,10 @@ func TestAccUserResourceValidateConfig(t *testing.T) {
 				Config:      saWithLoginType.String(t),
 				ExpectError: regexp.MustCompile(`login_type.+must be`),
 			},
+			{
+				Config:      unknownRole.String(t),
+				ExpectError: regexp.MustCompile(`"not-a-role" is not an assignable site role`),
+			},
 		},
 	})
 }
@@ -370,6 +557,13 @@ type testAccUserResourceConfig struct {
 	Password         *string
 	Suspended        *bool
 	IsServiceAccount *bool
+
+	QuietHoursSchedule *string
+	ThemePreference    *string
+	TerminalFont       *string
+
+	OnDestroy           *string
+	TransferTemplatesTo *string
 }
 
 func (c testAccUserResourceConfig) String(t *testing.T) string {
@@ -389,6 +583,16 @@ resource "coderd_user" "test" {
 	password   = {{orNull .Password}}
 	suspended  = {{orNull .Suspended}}
 	is_service_account = {{orNull .IsServiceAccount}}
+
+	quiet_hours_schedule = {{orNull .QuietHoursSchedule}}
+	on_destroy            = {{orNull .OnDestroy}}
+	transfer_templates_to = {{orNull .TransferTemplatesTo}}
+	{{- if .ThemePreference}}
+	appearance = {
+		theme_preference = {{orNull .ThemePreference}}
+		terminal_font    = {{orNull .TerminalFont}}
+	}
+	{{- end}}
 }
 `
 	// Define template functions
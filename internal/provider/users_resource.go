@@ -0,0 +1,544 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/coder/terraform-provider-coderd/internal/codersdkvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UsersResource{}
+var _ resource.ResourceWithModifyPlan = &UsersResource{}
+
+// defaultUsersParallelism bounds how many users are changed at once.
+const defaultUsersParallelism = 4
+
+var pathUsers = path.Root("users")
+
+type UsersResource struct {
+	*CoderdProviderData
+}
+
+// UsersResourceModel describes the resource data model.
+type UsersResourceModel struct {
+	Users       types.Map   `tfsdk:"users"`
+	Parallelism types.Int64 `tfsdk:"parallelism"`
+}
+
+// UsersResourceUser is a single entry of `users`. The username is its key.
+type UsersResourceUser struct {
+	ID        UUID         `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Email     types.String `tfsdk:"email"`
+	Roles     types.Set    `tfsdk:"roles"`
+	LoginType types.String `tfsdk:"login_type"`
+	Suspended types.Bool   `tfsdk:"suspended"`
+}
+
+var usersResourceUserType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":         UUIDType,
+		"name":       types.StringType,
+		"email":      types.StringType,
+		"roles":      types.SetType{ElemType: types.StringType},
+		"login_type": types.StringType,
+		"suspended":  types.BoolType,
+	},
+}
+
+// requiresRecreate reports whether changing from prior to u can only be done
+// by deleting and recreating the user, as with `coderd_user`.
+func (u UsersResourceUser) requiresRecreate(prior UsersResourceUser) bool {
+	return !u.Email.Equal(prior.Email) || !u.LoginType.Equal(prior.LoginType)
+}
+
+func (u UsersResourceUser) userModel(username string) UserResourceModel {
+	return UserResourceModel{
+		ID:                  u.ID,
+		Username:            types.StringValue(username),
+		Name:                u.Name,
+		Email:               u.Email,
+		Roles:               u.Roles,
+		LoginType:           u.LoginType,
+		Password:            types.StringNull(),
+		Suspended:           u.Suspended,
+		IsServiceAccount:    types.BoolValue(false),
+		QuietHoursSchedule:  types.StringNull(),
+		Appearance:          types.ObjectNull(userAppearanceType.AttrTypes),
+		GitSSHPublicKey:     types.StringNull(),
+		OnDestroy:           types.StringValue(userOnDestroyDelete),
+		TransferTemplatesTo: NewUUIDNull(),
+	}
+}
+
+func usersResourceUserFromModel(m UserResourceModel) UsersResourceUser {
+	return UsersResourceUser{
+		ID:        m.ID,
+		Name:      m.Name,
+		Email:     m.Email,
+		Roles:     m.Roles,
+		LoginType: m.LoginType,
+		Suspended: m.Suspended,
+	}
+}
+
+func NewUsersResource() resource.Resource {
+	return &UsersResource{}
+}
+
+func (r *UsersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (r *UsersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `A roster of users on the Coder deployment, managed from a single map keyed by username. Typically driven by ` + "`csvdecode(file(...))`" + ` or another system's export, so onboarding a team is one line per person rather than one ` + "`coderd_user`" + ` block.
+
+Users are created, updated, and deleted in parallel, and a failure for one user doesn't stop the others. If any user fails during the first apply, the users created by that apply are deleted again so the resource can be retried cleanly. On later applies, users that were changed successfully are recorded in state, and a failed user keeps its previous state, so the next apply retries only what failed.
+
+~> **Warning**
+Removing a user from ` + "`users`" + `, or changing their username, deletes the user. Changing a user's ` + "`email`" + ` or ` + "`login_type`" + ` deletes and recreates them, as it does for ` + "`coderd_user`" + `; a plan-time warning is emitted when this is about to happen.
+`,
+		Attributes: map[string]schema.Attribute{
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "The users, keyed by username.",
+				Required:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(codersdkvalidator.Name()),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							CustomType:          UUIDType,
+							Computed:            true,
+							MarkdownDescription: "User ID",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the user. Defaults to username.",
+							Optional:            true,
+							Computed:            true,
+							Validators: []validator.String{
+								codersdkvalidator.UserRealName(),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email address of the user.",
+							Required:            true,
+						},
+						"roles": schema.SetAttribute{
+							MarkdownDescription: "Roles assigned to the user, checked against the deployment at plan time as for `coderd_user`. If `null`, roles will not be managed by Terraform.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"login_type": schema.StringAttribute{
+							MarkdownDescription: "Type of login for the user. Valid types are `none`, `github`, and `oidc`. Users that log in with a password need a `coderd_user`, which manages the password.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("none"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("none", "github", "oidc"),
+							},
+						},
+						"suspended": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is suspended.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users changed at once. Defaults to %d.", defaultUsersParallelism),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultUsersParallelism),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+		},
+	}
+}
+
+func (r *UsersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider data",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.CoderdProviderData = data
+}
+
+// users returns the single-user resource whose logic manages each entry.
+func (r *UsersResource) users() *UserResource {
+	return &UserResource{data: r.CoderdProviderData, skipGitSSHKey: true}
+}
+
+func (r *UsersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform prior state data into the model
+	var data UsersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state, diags := usersFromMap(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := newUsersResult(state)
+	resp.Diagnostics.Append(forEachUser(slices.Collect(maps.Keys(state)), data.Parallelism, func(username string) (diags diag.Diagnostics) {
+		model := state[username].userModel(username)
+		found, diags := r.users().read(ctx, &model)
+		if diags.HasError() {
+			return diags
+		}
+		if !found {
+			result.remove(username)
+			return diags
+		}
+		result.set(username, usersResourceUserFromModel(model))
+		return diags
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Users, diags = result.value(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UsersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data UsersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan, diags := usersFromMap(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "creating users", map[string]any{
+		"count": len(plan),
+	})
+	result := newUsersResult(nil)
+	resp.Diagnostics.Append(forEachUser(slices.Collect(maps.Keys(plan)), data.Parallelism, func(username string) diag.Diagnostics {
+		model := plan[username].userModel(username)
+		diags := r.users().create(ctx, &model)
+		if !model.ID.IsUnknown() && !model.ID.IsNull() {
+			// Record the user even if a later step failed, so a rollback
+			// deletes it.
+			result.set(username, usersResourceUserFromModel(model))
+		}
+		return diags
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		// Saving partial state would taint the whole resource, and the next
+		// apply would replace every user. Roll back instead, so the apply can
+		// simply be retried.
+		created := result.snapshot()
+		tflog.Info(ctx, "rolling back created users", map[string]any{
+			"count": len(created),
+		})
+		resp.Diagnostics.Append(forEachUser(slices.Collect(maps.Keys(created)), data.Parallelism, func(username string) diag.Diagnostics {
+			model := created[username].userModel(username)
+			return r.users().deleteUser(ctx, &model)
+		})...)
+		return
+	}
+	tflog.Info(ctx, "successfully created users")
+
+	data.Users, diags = result.value(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UsersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan and prior state data into the models
+	var data, prior UsersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan, diags := usersFromMap(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	state, diags := usersFromMap(ctx, prior.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Start from prior state, so a failed change leaves its user as it was.
+	result := newUsersResult(state)
+	usernames := slices.Collect(maps.Keys(state))
+	for username := range plan {
+		if _, ok := state[username]; !ok {
+			usernames = append(usernames, username)
+		}
+	}
+	tflog.Info(ctx, "updating users", map[string]any{
+		"count": len(usernames),
+	})
+	resp.Diagnostics.Append(forEachUser(usernames, data.Parallelism, func(username string) (diags diag.Diagnostics) {
+		planned, inPlan := plan[username]
+		existing, inState := state[username]
+
+		if inState && (!inPlan || planned.requiresRecreate(existing)) {
+			model := existing.userModel(username)
+			diags.Append(r.users().deleteUser(ctx, &model)...)
+			if diags.HasError() {
+				return diags
+			}
+			result.remove(username)
+			if !inPlan {
+				return diags
+			}
+			inState = false
+		}
+
+		model := planned.userModel(username)
+		if inState {
+			if planned.ID.IsUnknown() {
+				model.ID = existing.ID
+			}
+			diags.Append(r.users().update(ctx, &model)...)
+			if diags.HasError() {
+				return diags
+			}
+		} else {
+			model.ID = NewUUIDNull()
+			diags.Append(r.users().create(ctx, &model)...)
+			if model.ID.IsNull() || model.ID.IsUnknown() {
+				return diags
+			}
+		}
+		// A user that was created but failed a later step is still recorded,
+		// so it isn't orphaned; its diagnostics fail the apply.
+		result.set(username, usersResourceUserFromModel(model))
+		return diags
+	})...)
+	tflog.Info(ctx, "finished updating users")
+
+	data.Users, diags = result.value(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state, even after an error, so that
+	// successful changes are kept.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UsersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data UsersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state, diags := usersFromMap(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "deleting users", map[string]any{
+		"count": len(state),
+	})
+	resp.Diagnostics.Append(forEachUser(slices.Collect(maps.Keys(state)), data.Parallelism, func(username string) diag.Diagnostics {
+		model := state[username].userModel(username)
+		return r.users().deleteUser(ctx, &model)
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "successfully deleted users")
+}
+
+// ModifyPlan checks each user's roles and login type against the deployment,
+// as `coderd_user` does, and warns about users that will be recreated.
+func (r *UsersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// A destroy plan has a null plan. Nothing to check.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Configure() has not run during the validate walk.
+	if r.CoderdProviderData == nil {
+		return
+	}
+
+	var data UsersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Users.IsUnknown() {
+		return
+	}
+	plan, diags := usersFromMap(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	state := map[string]UsersResourceUser{}
+	if !req.State.Raw.IsNull() {
+		var prior UsersResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state, diags = usersFromMap(ctx, prior.Users)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, username := range slices.Sorted(maps.Keys(plan)) {
+		planned := plan[username]
+		existing, inState := state[username]
+		userPath := pathUsers.AtMapKey(username)
+
+		if setFullyKnown(planned.Roles) {
+			var roles, prior []string
+			resp.Diagnostics.Append(planned.Roles.ElementsAs(ctx, &roles, false)...)
+			if inState && setFullyKnown(existing.Roles) {
+				resp.Diagnostics.Append(existing.Roles.ElementsAs(ctx, &prior, false)...)
+			}
+			added := slices.DeleteFunc(roles, func(role string) bool {
+				return slices.Contains(prior, role)
+			})
+			resp.Diagnostics.Append(r.validateSiteRoles(ctx, userPath.AtName("roles"), added)...)
+		}
+		if !planned.LoginType.IsUnknown() && (!inState || !planned.LoginType.Equal(existing.LoginType)) {
+			resp.Diagnostics.Append(r.validateLoginType(ctx, userPath.AtName("login_type"), planned.LoginType.ValueString())...)
+		}
+
+		if inState && !planned.Email.IsUnknown() && !planned.LoginType.IsUnknown() && planned.requiresRecreate(existing) {
+			// The recreated user gets a new ID, which the state's ID
+			// carried by UseStateForUnknown would contradict.
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, userPath.AtName("id"), NewUUIDUnknown())...)
+			resp.Diagnostics.AddAttributeWarning(userPath,
+				"User Will Be Recreated",
+				fmt.Sprintf("Changing the email or login type of %q deletes the user and creates a new one. "+
+					"Their workspaces must be deleted first, or the deletion fails.", username),
+			)
+		}
+	}
+}
+
+// usersFromMap decodes a `users` map.
+func usersFromMap(ctx context.Context, m types.Map) (map[string]UsersResourceUser, diag.Diagnostics) {
+	users := map[string]UsersResourceUser{}
+	if m.IsNull() || m.IsUnknown() {
+		return users, nil
+	}
+	diags := m.ElementsAs(ctx, &users, false)
+	return users, diags
+}
+
+// usersResult collects the outcome of concurrent per-user operations.
+type usersResult struct {
+	mu    sync.Mutex
+	users map[string]UsersResourceUser
+}
+
+func newUsersResult(initial map[string]UsersResourceUser) *usersResult {
+	users := make(map[string]UsersResourceUser, len(initial))
+	maps.Copy(users, initial)
+	return &usersResult{users: users}
+}
+
+func (r *usersResult) set(username string, user UsersResourceUser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[username] = user
+}
+
+func (r *usersResult) remove(username string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, username)
+}
+
+func (r *usersResult) snapshot() map[string]UsersResourceUser {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.users)
+}
+
+func (r *usersResult) value(ctx context.Context) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, usersResourceUserType, r.snapshot())
+}
+
+// forEachUser calls fn for every username, with at most parallelism calls in
+// flight, and returns each call's diagnostics attached to its entry in
+// `users`.
+func forEachUser(usernames []string, parallelism types.Int64, fn func(username string) diag.Diagnostics) (diags diag.Diagnostics) {
+	limit := int(parallelism.ValueInt64())
+	if limit < 1 {
+		limit = defaultUsersParallelism
+	}
+	slices.Sort(usernames)
+
+	results := make([]diag.Diagnostics, len(usernames))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, username := range usernames {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = fn(username)
+		})
+	}
+	wg.Wait()
+
+	for i, username := range usernames {
+		for _, d := range results[i] {
+			diags.Append(diag.WithPath(pathUsers.AtMapKey(username), d))
+		}
+	}
+	return diags
+}
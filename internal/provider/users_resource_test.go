@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccUsersResource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "users_acc")
+
+	type user struct {
+		username, name, email, roles string
+		suspended                    bool
+	}
+	cfg := func(users ...user) string {
+		var b strings.Builder
+		fmt.Fprintf(&b, `
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+resource "coderd_users" "test" {
+	parallelism = 2
+	users = {
+`, client.URL.String(), client.SessionToken())
+		for _, u := range users {
+			fmt.Fprintf(&b, `		%q = {
+			name      = %q
+			email     = %q
+			roles     = %s
+			suspended = %t
+		}
+`, u.username, u.name, u.email, u.roles, u.suspended)
+		}
+		b.WriteString("\t}\n}\n")
+		return b.String()
+	}
+
+	alice := user{username: "alice", name: "Alice", email: "alice@coder.com", roles: `["auditor"]`}
+	bob := user{username: "bob", name: "Bob", email: "bob@coder.com", roles: "[]"}
+	carol := user{username: "carol", name: "Carol", email: "carol@coder.com", roles: "null"}
+	// bobID is bob's ID before the email change recreates the user.
+	var bobID string
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			for _, username := range []string{"alice", "bob", "carol", "dave"} {
+				if _, err := client.User(ctx, username); err == nil {
+					return fmt.Errorf("expected user %q to be deleted", username)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(alice, bob, carol),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_users.test", "users.%", "3"),
+					resource.TestCheckResourceAttrSet("coderd_users.test", "users.alice.id"),
+					resource.TestCheckResourceAttr("coderd_users.test", "users.alice.login_type", "none"),
+					resource.TestCheckTypeSetElemAttr("coderd_users.test", "users.alice.roles.*", "auditor"),
+					resource.TestCheckResourceAttr("coderd_users.test", "users.bob.name", "Bob"),
+					resource.TestCheckNoResourceAttr("coderd_users.test", "users.carol.roles"),
+				),
+			},
+			// Suspend one user, rename another, remove a third and add a
+			// fourth, all in one apply.
+			{
+				Config: func() string {
+					alice.suspended = true
+					bob.name = "Robert"
+					dave := user{username: "dave", name: "Dave", email: "dave@coder.com", roles: `["template-admin"]`}
+					return cfg(alice, bob, dave)
+				}(),
+				Check: func(s *terraform.State) error {
+					bobID = s.RootModule().Resources["coderd_users.test"].Primary.Attributes["users.bob.id"]
+					alice, err := client.User(ctx, "alice")
+					if err != nil {
+						return err
+					}
+					if alice.Status != codersdk.UserStatusSuspended {
+						return fmt.Errorf("expected alice to be suspended, got %q", alice.Status)
+					}
+					bob, err := client.User(ctx, "bob")
+					if err != nil {
+						return err
+					}
+					if bob.Name != "Robert" {
+						return fmt.Errorf("expected bob to be renamed, got %q", bob.Name)
+					}
+					if _, err := client.User(ctx, "carol"); err == nil {
+						return fmt.Errorf("expected carol to be deleted")
+					}
+					_, err = client.User(ctx, "dave")
+					return err
+				},
+			},
+			// Changing an email recreates the user.
+			{
+				Config: func() string {
+					bob.email = "robert@coder.com"
+					dave := user{username: "dave", name: "Dave", email: "dave@coder.com", roles: `["template-admin"]`}
+					return cfg(alice, bob, dave)
+				}(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_users.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("coderd_users.test", tfjsonpath.New("users").AtMapKey("bob").AtMapKey("id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderd_users.test", "users.bob.email", "robert@coder.com"),
+					func(s *terraform.State) error {
+						bob, err := client.User(ctx, "bob")
+						if err != nil {
+							return err
+						}
+						if bob.ID.String() == bobID {
+							return fmt.Errorf("expected bob to be recreated with a new ID, got %s again", bobID)
+						}
+						return resource.TestCheckResourceAttr("coderd_users.test", "users.bob.id", bob.ID.String())(s)
+					},
+				),
+			},
+			// Unassignable roles fail at plan time, before any user changes.
+			{
+				Config: func() string {
+					bad := carol
+					bad.roles = `["not-a-role"]`
+					return cfg(alice, bad)
+				}(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"not-a-role" is not an assignable site role`),
+			},
+		},
+	})
+}
@@ -86,6 +86,14 @@ func computeDirectoryHash(directory string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// computeSourceVersionHash returns the value tracked in `directory_hash` for a
+// version created from an existing template version, so that changing the
+// source triggers a new version just like changing the directory contents.
+func computeSourceVersionHash(sourceVersionID uuid.UUID) string {
+	hash := sha256.Sum256([]byte("source_version_id:" + sourceVersionID.String()))
+	return hex.EncodeToString(hash[:])
+}
+
 // memberDiff returns the members to add and remove from the group, given the
 // current members and the planned members. plannedMembers is deliberately our
 // custom type, as Terraform cannot automatically produce `[]uuid.UUID` from a
@@ -135,6 +143,30 @@ func stringValueOrNull(s string) types.String {
 	return types.StringValue(s)
 }
 
+// int64ValueOrNull returns types.Int64Null() if v is zero,
+// otherwise types.Int64Value(v).
+func int64ValueOrNull(v int64) types.Int64 {
+	if v == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(v)
+}
+
+// setFullyKnown reports whether s is neither null nor unknown and has no
+// unknown elements, such as one taken from another resource, which
+// ElementsAs can't decode.
+func setFullyKnown(s types.Set) bool {
+	if s.IsNull() || s.IsUnknown() {
+		return false
+	}
+	for _, element := range s.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
 // stringPtrOrNil returns nil for null or unknown strings.
 // ValueStringPointer returns &"" for unknown, which can accidentally send a value.
 func stringPtrOrNil(v types.String) *string {
@@ -3,6 +3,7 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
 )
@@ -34,3 +35,16 @@ func TestBoolPtrOrNil(t *testing.T) {
 		require.True(t, *value)
 	})
 }
+
+func TestSetFullyKnown(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, setFullyKnown(types.SetNull(types.StringType)))
+	require.False(t, setFullyKnown(types.SetUnknown(types.StringType)))
+	require.False(t, setFullyKnown(types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("auditor"),
+		types.StringUnknown(),
+	})))
+	require.True(t, setFullyKnown(types.SetValueMust(types.StringType, []attr.Value{})))
+	require.True(t, setFullyKnown(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("auditor")})))
+}
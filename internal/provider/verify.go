@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// defaultVerifyTimeout bounds a connectivity check when `verify.timeout` is
+// omitted.
+const defaultVerifyTimeout = 10 * time.Second
+
+// VerifyModel is the `verify` attribute of resources that check, after
+// create and update, that the upstream service they configure is reachable.
+type VerifyModel struct {
+	Timeout     types.String `tfsdk:"timeout"`
+	FailOnError types.Bool   `tfsdk:"fail_on_error"`
+}
+
+// verifySchema returns the `verify` attribute. check completes the sentence
+// "After every create and update, check that ...".
+func verifySchema(check string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "After every create and update, check that " + check + ". " +
+			"The check runs from the machine running Terraform, not from the Coder server, so it can't catch network paths that only the Coder server lacks. " +
+			"A failed check is reported as a warning unless `fail_on_error` is set. Omit to skip the check.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the check, such as `30s`. Defaults to `10s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				MarkdownDescription: "Whether a failed check fails the apply. The change to Coder is kept either way; a resource that failed its check on creation is marked tainted, so the next apply replaces it. Defaults to false.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// verifyAttrTypes are the attribute types of the `verify` object.
+var verifyAttrTypes = map[string]attr.Type{
+	"timeout":       types.StringType,
+	"fail_on_error": types.BoolType,
+}
+
+// verifyModel decodes a `verify` object. It returns nil if `verify` is
+// omitted, or unknown because it is built from another resource's attributes,
+// so the other checks of a plan still run while only the check waits.
+func verifyModel(ctx context.Context, v types.Object, diags *diag.Diagnostics) *VerifyModel {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var verify VerifyModel
+	diags.Append(v.As(ctx, &verify, basetypes.ObjectAsOptions{})...)
+	return &verify
+}
+
+// verifyOrNull returns v, or a typed null for the zero value of a model that
+// wasn't decoded from Terraform, such as the one built on import.
+func verifyOrNull(v types.Object) types.Object {
+	if v.IsNull() {
+		return types.ObjectNull(verifyAttrTypes)
+	}
+	return v
+}
+
+func (v *VerifyModel) timeout() time.Duration {
+	if v.Timeout.IsNull() || v.Timeout.IsUnknown() {
+		return defaultVerifyTimeout
+	}
+	d, err := time.ParseDuration(v.Timeout.ValueString())
+	if err != nil || d <= 0 {
+		return defaultVerifyTimeout
+	}
+	return d
+}
+
+// reportFailure adds a failed check to diags: as an error if the
+// configuration asks to fail on it, and as a warning otherwise.
+func (v *VerifyModel) reportFailure(diags *diag.Diagnostics, summary, detail string) {
+	if v.FailOnError.ValueBool() {
+		diags.AddAttributeError(path.Root("verify"), summary, detail)
+		return
+	}
+	diags.AddAttributeWarning(path.Root("verify"), summary, detail)
+}
+
+// verifyHTTPError is an unexpected HTTP response from an upstream service.
+type verifyHTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *verifyHTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// Unauthorized reports whether the service rejected the request's
+// credentials, which still proves that it is reachable.
+func (e *verifyHTTPError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+func newVerifyHTTPError(resp *http.Response) *verifyHTTPError {
+	// The body is only for the diagnostic, so keep it short.
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &verifyHTTPError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+// verifyAIProvider checks that an AI provider's API at baseURL answers a
+// models listing authenticated with apiKey. Types Coder authenticates
+// without an API key, and an empty apiKey, only get a reachability check,
+// which any HTTP response passes.
+func verifyAIProvider(ctx context.Context, client *http.Client, providerType codersdk.AIProviderType, baseURL, apiKey string) error {
+	base := strings.TrimSuffix(baseURL, "/")
+	modelsURL := base + "/models"
+	header := http.Header{}
+	switch providerType {
+	case codersdk.AIProviderTypeAnthropic:
+		// Anthropic base URLs usually omit the API version.
+		if !strings.HasSuffix(base, "/v1") {
+			modelsURL = base + "/v1/models"
+		}
+		header.Set("x-api-key", apiKey)
+		header.Set("anthropic-version", "2023-06-01")
+	case codersdk.AIProviderTypeAzure:
+		header.Set("api-key", apiKey)
+	case codersdk.AIProviderTypeGoogle:
+		header.Set("x-goog-api-key", apiKey)
+	case codersdk.AIProviderTypeBedrock, codersdk.AIProviderTypeCopilot:
+		// Bedrock requests are signed with AWS credentials, and Copilot
+		// uses each user's GitHub token.
+		apiKey = ""
+	default:
+		header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	if apiKey == "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := newVerifyHTTPError(resp)
+		if err.Unauthorized() {
+			return fmt.Errorf("the provider rejected the API key: %w", err)
+		}
+		return fmt.Errorf("listing models at %s: %w", modelsURL, err)
+	}
+	return nil
+}
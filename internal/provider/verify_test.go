@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAIProvider(t *testing.T) {
+	t.Parallel()
+
+	// The fake upstream lists models only for requests carrying the
+	// expected key in the expected header.
+	newUpstream := func(t *testing.T, path, header, value string) *httptest.Server {
+		t.Helper()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Header.Get(header) != value {
+				http.Error(w, "invalid api key", http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	t.Run("OpenAI", func(t *testing.T) {
+		t.Parallel()
+		srv := newUpstream(t, "/v1/models", "Authorization", "Bearer sk-test")
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeOpenAI, srv.URL+"/v1/", "sk-test")
+		require.NoError(t, err)
+	})
+
+	t.Run("AnthropicAddsVersion", func(t *testing.T) {
+		t.Parallel()
+		srv := newUpstream(t, "/v1/models", "x-api-key", "sk-ant-test")
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeAnthropic, srv.URL, "sk-ant-test")
+		require.NoError(t, err)
+	})
+
+	t.Run("RejectedKey", func(t *testing.T) {
+		t.Parallel()
+		srv := newUpstream(t, "/v1/models", "Authorization", "Bearer sk-test")
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeOpenAI, srv.URL+"/v1", "sk-wrong")
+		require.ErrorContains(t, err, "rejected the API key")
+		require.ErrorContains(t, err, "invalid api key")
+	})
+
+	t.Run("WrongBaseURL", func(t *testing.T) {
+		t.Parallel()
+		srv := newUpstream(t, "/v1/models", "Authorization", "Bearer sk-test")
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeOpenAI, srv.URL+"/v2", "sk-test")
+		require.ErrorContains(t, err, "unexpected status 404")
+	})
+
+	t.Run("ReachabilityOnly", func(t *testing.T) {
+		t.Parallel()
+		srv := newUpstream(t, "/v1/models", "Authorization", "Bearer sk-test")
+		// Any response proves the upstream is reachable without a key, and
+		// Bedrock never sends one.
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeOpenAI, srv.URL, "")
+		require.NoError(t, err)
+		err = verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeBedrock, srv.URL, "sk-test")
+		require.NoError(t, err)
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		err := verifyAIProvider(context.Background(), srv.Client(), codersdk.AIProviderTypeOpenAI, srv.URL, "")
+		require.Error(t, err)
+	})
+}
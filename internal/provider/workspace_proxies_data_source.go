@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkspaceProxiesDataSource{}
+
+func NewWorkspaceProxiesDataSource() datasource.DataSource {
+	return &WorkspaceProxiesDataSource{}
+}
+
+// WorkspaceProxiesDataSource defines the data source implementation.
+type WorkspaceProxiesDataSource struct {
+	data *CoderdProviderData
+}
+
+// WorkspaceProxiesDataSourceModel describes the data source data model.
+type WorkspaceProxiesDataSourceModel struct {
+	Proxies []WorkspaceProxyRegion `tfsdk:"proxies"`
+}
+
+type WorkspaceProxyRegion struct {
+	ID               UUID         `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	Icon             types.String `tfsdk:"icon"`
+	URL              types.String `tfsdk:"url"`
+	WildcardHostname types.String `tfsdk:"wildcard_hostname"`
+	DerpEnabled      types.Bool   `tfsdk:"derp_enabled"`
+	DerpOnly         types.Bool   `tfsdk:"derp_only"`
+	Healthy          types.Bool   `tfsdk:"healthy"`
+	Status           types.String `tfsdk:"status"`
+	Version          types.String `tfsdk:"version"`
+	CheckedAt        types.Int64  `tfsdk:"checked_at"`
+}
+
+func (d *WorkspaceProxiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_proxies"
+}
+
+func (d *WorkspaceProxiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `The regions users can connect to workspaces through: the primary deployment and each of its workspace proxies.
+
+~> **Warning**
+Workspace proxies require an Enterprise or Premium license.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"proxies": schema.ListNestedAttribute{
+				MarkdownDescription: "The regions, including the primary deployment.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"display_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"icon": schema.StringAttribute{
+							Computed: true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL the region serves apps from. Users are routed to the region with the lowest latency to this URL.",
+							Computed:            true,
+						},
+						"wildcard_hostname": schema.StringAttribute{
+							MarkdownDescription: "The wildcard hostname the region serves subdomain apps from.",
+							Computed:            true,
+						},
+						"derp_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the region runs a DERP relay for workspace connections.",
+							Computed:            true,
+						},
+						"derp_only": schema.BoolAttribute{
+							MarkdownDescription: "Whether the region only relays workspace connections, and doesn't serve apps.",
+							Computed:            true,
+						},
+						"healthy": schema.BoolAttribute{
+							MarkdownDescription: "Whether the region passed its latest health check.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The result of the region's latest health check. Can be `ok`, `unreachable`, `unhealthy` or `unregistered`.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The Coder version the region runs.",
+							Computed:            true,
+						},
+						"checked_at": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp of the region's latest health check. Null if the region has never been checked.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkspaceProxiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *WorkspaceProxiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkspaceProxiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regions, err := d.data.Client.WorkspaceProxies(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workspace proxies, got error: %s", err))
+		return
+	}
+
+	proxies := make([]WorkspaceProxyRegion, 0, len(regions.Regions))
+	for _, proxy := range regions.Regions {
+		// Regions that have never been health-checked have no check time.
+		checkedAt := types.Int64Null()
+		if !proxy.Status.CheckedAt.IsZero() {
+			checkedAt = types.Int64Value(proxy.Status.CheckedAt.Unix())
+		}
+		proxies = append(proxies, WorkspaceProxyRegion{
+			ID:               UUIDValue(proxy.ID),
+			Name:             types.StringValue(proxy.Name),
+			DisplayName:      types.StringValue(proxy.DisplayName),
+			Icon:             types.StringValue(proxy.IconURL),
+			URL:              types.StringValue(proxy.PathAppURL),
+			WildcardHostname: types.StringValue(proxy.WildcardHostname),
+			DerpEnabled:      types.BoolValue(proxy.DerpEnabled),
+			DerpOnly:         types.BoolValue(proxy.DerpOnly),
+			Healthy:          types.BoolValue(proxy.Healthy),
+			Status:           types.StringValue(string(proxy.Status.Status)),
+			Version:          types.StringValue(proxy.Version),
+			CheckedAt:        checkedAt,
+		})
+	}
+	data.Proxies = proxies
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
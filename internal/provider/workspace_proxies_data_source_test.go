@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccWorkspaceProxiesDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "ws_proxies_data_acc", integration.UseLicense)
+
+	proxy, err := client.CreateWorkspaceProxy(ctx, codersdk.CreateWorkspaceProxyRequest{
+		Name:        "sydney",
+		DisplayName: "Australia (Sydney)",
+		Icon:        "/emojis/1f1e6-1f1fa.png",
+	})
+	require.NoError(t, err)
+
+	cfg := fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_workspace_proxies" "test" {}
+`, client.URL.String(), client.SessionToken())
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_workspace_proxies.test", "proxies.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.coderd_workspace_proxies.test", "proxies.*", map[string]string{
+						"name":    "primary",
+						"healthy": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.coderd_workspace_proxies.test", "proxies.*", map[string]string{
+						"id":           proxy.Proxy.ID.String(),
+						"name":         "sydney",
+						"display_name": "Australia (Sydney)",
+						"healthy":      "false",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/retry"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkspaceProxyDataSource{}
+
+func NewWorkspaceProxyDataSource() datasource.DataSource {
+	return &WorkspaceProxyDataSource{}
+}
+
+// WorkspaceProxyDataSource defines the data source implementation.
+type WorkspaceProxyDataSource struct {
+	data *CoderdProviderData
+}
+
+// WorkspaceProxyDataSourceModel describes the data source data model.
+type WorkspaceProxyDataSourceModel struct {
+	ID               UUID         `tfsdk:"id"`
+	WaitForHealthy   types.String `tfsdk:"wait_for_healthy"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	Icon             types.String `tfsdk:"icon"`
+	URL              types.String `tfsdk:"url"`
+	WildcardHostname types.String `tfsdk:"wildcard_hostname"`
+	DerpEnabled      types.Bool   `tfsdk:"derp_enabled"`
+	Healthy          types.Bool   `tfsdk:"healthy"`
+	Status           types.String `tfsdk:"status"`
+	Version          types.String `tfsdk:"version"`
+}
+
+func (d *WorkspaceProxyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_proxy"
+}
+
+func (d *WorkspaceProxyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `A workspace proxy, as registered with the Coder deployment.
+
+To run something, such as DNS or certificate modules, only once a proxy has registered, read the proxy with ` + "`wait_for_healthy`" + ` and ` + "`depends_on`" + ` the proxy's own deployment, and use this data source's attributes.
+
+~> **Warning**
+Workspace proxies require an Enterprise or Premium license.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workspace proxy.",
+				CustomType:          UUIDType,
+				Required:            true,
+			},
+			"wait_for_healthy": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the proxy to register and report healthy, as a Go duration string such as `10m`. If the proxy isn't healthy in time, the read fails. If null, the proxy is read as it is.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the workspace proxy.",
+				Computed:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the workspace proxy.",
+				Computed:            true,
+			},
+			"icon": schema.StringAttribute{
+				MarkdownDescription: "The icon of the workspace proxy.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL the proxy serves apps from, as reported by the proxy when it registers. Empty until it first registers.",
+				Computed:            true,
+			},
+			"wildcard_hostname": schema.StringAttribute{
+				MarkdownDescription: "The wildcard hostname the proxy serves subdomain apps from, such as `*.sydney.example.com`, as reported by the proxy when it registers. Empty until it first registers.",
+				Computed:            true,
+			},
+			"derp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the proxy runs a DERP relay for workspace connections.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the proxy passed its latest health check.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The result of the proxy's latest health check. Can be `ok`, `unreachable`, `unhealthy` or `unregistered`.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The Coder version the proxy runs.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WorkspaceProxyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *WorkspaceProxyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkspaceProxyDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.data.Client
+	var proxy codersdk.WorkspaceProxy
+	var err error
+	if data.WaitForHealthy.IsNull() {
+		proxy, err = client.WorkspaceProxyByID(ctx, data.ID.ValueUUID())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workspace proxy, got error: %s", err))
+			return
+		}
+	} else {
+		// Validated at plan time by durationValidator.
+		timeout, _ := time.ParseDuration(data.WaitForHealthy.ValueString())
+		proxy, err = waitForHealthyProxy(ctx, client, data.ID.ValueUUID(), timeout)
+		if err != nil {
+			resp.Diagnostics.AddError("Workspace Proxy Not Healthy", fmt.Sprintf("Workspace proxy %s didn't become healthy within %s: %s", data.ID.ValueString(), timeout, err))
+			return
+		}
+	}
+
+	data.Name = types.StringValue(proxy.Name)
+	data.DisplayName = types.StringValue(proxy.DisplayName)
+	data.Icon = types.StringValue(proxy.IconURL)
+	data.URL = types.StringValue(proxy.PathAppURL)
+	data.WildcardHostname = types.StringValue(proxy.WildcardHostname)
+	data.DerpEnabled = types.BoolValue(proxy.DerpEnabled)
+	data.Healthy = types.BoolValue(proxy.Healthy)
+	data.Status = types.StringValue(string(proxy.Status.Status))
+	data.Version = types.StringValue(proxy.Version)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForHealthyProxy waits for the proxy to register with the deployment and
+// pass a health check, returning it once it has.
+func waitForHealthyProxy(ctx context.Context, client *codersdk.Client, proxyID uuid.UUID, timeout time.Duration) (codersdk.WorkspaceProxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var status codersdk.ProxyHealthStatus
+	for retrier := retry.New(time.Second, 10*time.Second); retrier.Wait(ctx); {
+		proxy, err := client.WorkspaceProxyByID(ctx, proxyID)
+		if err != nil {
+			return codersdk.WorkspaceProxy{}, err
+		}
+		if proxy.Healthy {
+			return proxy, nil
+		}
+		status = proxy.Status.Status
+	}
+	return codersdk.WorkspaceProxy{}, fmt.Errorf("last status was %q", status)
+}
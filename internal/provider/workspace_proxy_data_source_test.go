@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccWorkspaceProxyDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "ws_proxy_data_acc", integration.UseLicense)
+
+	proxy, err := client.CreateWorkspaceProxy(ctx, codersdk.CreateWorkspaceProxyRequest{
+		Name:        "sydney",
+		DisplayName: "Australia (Sydney)",
+		Icon:        "/emojis/1f1e6-1f1fa.png",
+	})
+	require.NoError(t, err)
+
+	cfg := func(waitForHealthy string) string {
+		return fmt.Sprintf(`
+provider coderd {
+	url   = %q
+	token = %q
+}
+
+data "coderd_workspace_proxy" "test" {
+	id               = %q
+	wait_for_healthy = %s
+}
+`, client.URL.String(), client.SessionToken(), proxy.Proxy.ID.String(), waitForHealthy)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg("null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderd_workspace_proxy.test", "name", "sydney"),
+					resource.TestCheckResourceAttr("data.coderd_workspace_proxy.test", "display_name", "Australia (Sydney)"),
+					resource.TestCheckResourceAttr("data.coderd_workspace_proxy.test", "healthy", "false"),
+					resource.TestCheckResourceAttr("data.coderd_workspace_proxy.test", "url", ""),
+				),
+			},
+			// The proxy is never deployed, so it never becomes healthy.
+			{
+				Config:      cfg(`"5s"`),
+				ExpectError: regexp.MustCompile(`didn't become healthy within 5s`),
+			},
+		},
+	})
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceProxyResource{}
+var _ resourceWithEntitlements = &WorkspaceProxyResource{}
 
 func NewWorkspaceProxyResource() resource.Resource {
 	return &WorkspaceProxyResource{}
@@ -26,11 +30,35 @@ type WorkspaceProxyResource struct {
 
 // WorkspaceProxyResourceModel describes the resource data model.
 type WorkspaceProxyResourceModel struct {
-	ID           UUID         `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	DisplayName  types.String `tfsdk:"display_name"`
-	Icon         types.String `tfsdk:"icon"`
-	SessionToken types.String `tfsdk:"session_token"`
+	ID               UUID         `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	Icon             types.String `tfsdk:"icon"`
+	SessionToken     types.String `tfsdk:"session_token"`
+	TokenRotation    types.String `tfsdk:"token_rotation_trigger"`
+	URL              types.String `tfsdk:"url"`
+	WildcardHostname types.String `tfsdk:"wildcard_hostname"`
+	DerpEnabled      types.Bool   `tfsdk:"derp_enabled"`
+	Healthy          types.Bool   `tfsdk:"healthy"`
+	Status           types.String `tfsdk:"status"`
+	Version          types.String `tfsdk:"version"`
+}
+
+// setRegistration fills in the attributes the proxy reports when it
+// registers with the deployment.
+func (m *WorkspaceProxyResourceModel) setRegistration(proxy codersdk.WorkspaceProxy) {
+	m.URL = types.StringValue(proxy.PathAppURL)
+	m.WildcardHostname = types.StringValue(proxy.WildcardHostname)
+	m.DerpEnabled = types.BoolValue(proxy.DerpEnabled)
+	m.setHealth(proxy)
+}
+
+// setHealth fills in the attributes reflecting the proxy's latest health
+// check.
+func (m *WorkspaceProxyResourceModel) setHealth(proxy codersdk.WorkspaceProxy) {
+	m.Healthy = types.BoolValue(proxy.Healthy)
+	m.Status = types.StringValue(string(proxy.Status.Status))
+	m.Version = types.StringValue(proxy.Version)
 }
 
 func (r *WorkspaceProxyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -39,7 +67,10 @@ func (r *WorkspaceProxyResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *WorkspaceProxyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "A Workspace Proxy for the Coder deployment.",
+		MarkdownDescription: "A Workspace Proxy for the Coder deployment.\n\n" +
+			"The proxy itself must be deployed separately, with `session_token`. Its `url`, `wildcard_hostname` " +
+			"and `derp_enabled` are empty until it first registers with the deployment. To wait for it to register, " +
+			"use the `coderd_workspace_proxy` data source.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -71,6 +102,43 @@ func (r *WorkspaceProxyResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"token_rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value that regenerates `session_token` whenever it changes, such as the ID of a `time_rotating` resource. The proxy keeps its ID, but must be redeployed with the new token before it can reconnect.",
+				Optional:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL the proxy serves apps from, as reported by the proxy when it registers.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wildcard_hostname": schema.StringAttribute{
+				MarkdownDescription: "The wildcard hostname the proxy serves subdomain apps from, such as `*.sydney.example.com`, as reported by the proxy when it registers.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"derp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the proxy runs a DERP relay for workspace connections.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the proxy passed its latest health check.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The result of the proxy's latest health check. Can be `ok`, `unreachable`, `unhealthy` or `unregistered`.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The Coder version the proxy runs.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -95,6 +163,35 @@ func (r *WorkspaceProxyResource) Configure(ctx context.Context, req resource.Con
 	r.data = data
 }
 
+// Entitlements implements resourceWithEntitlements.
+func (r *WorkspaceProxyResource) Entitlements() []entitlement {
+	return []entitlement{{
+		Feature: codersdk.FeatureWorkspaceProxy,
+		Usage:   "create workspace proxies",
+	}}
+}
+
+func (r *WorkspaceProxyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() {
+		var state, plan types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("token_rotation_trigger"), &state)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("token_rotation_trigger"), &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Update regenerates the token, so it can't be planned from state.
+		if !plan.Equal(state) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("session_token"), types.StringUnknown())...)
+		}
+	}
+
+	// Configure() has not run during the validate walk.
+	if r.data == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.data.checkEntitlements(ctx, req.Plan, r.Entitlements())...)
+}
+
 func (r *WorkspaceProxyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data WorkspaceProxyResourceModel
 
@@ -104,8 +201,8 @@ func (r *WorkspaceProxyResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	if !r.data.FeatureEnabled(codersdk.FeatureWorkspaceProxy) {
-		resp.Diagnostics.AddError("Feature not enabled", "Your license is not entitled to create workspace proxies.")
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -125,6 +222,7 @@ func (r *WorkspaceProxyResource) Create(ctx context.Context, req resource.Create
 	data.DisplayName = types.StringValue(wsp.Proxy.DisplayName)
 	data.Icon = types.StringValue(wsp.Proxy.IconURL)
 	data.SessionToken = types.StringValue(wsp.ProxyToken)
+	data.setRegistration(wsp.Proxy)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -156,6 +254,7 @@ func (r *WorkspaceProxyResource) Read(ctx context.Context, req resource.ReadRequ
 	data.Name = types.StringValue(wsp.Name)
 	data.DisplayName = types.StringValue(wsp.DisplayName)
 	data.Icon = types.StringValue(wsp.IconURL)
+	data.setRegistration(wsp)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -171,14 +270,26 @@ func (r *WorkspaceProxyResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	resp.Diagnostics.Append(r.data.checkAppliedEntitlements(ctx, req.Plan, r.Entitlements())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state WorkspaceProxyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client := r.data.Client
 
+	regenerateToken := !data.TokenRotation.Equal(state.TokenRotation)
 	wsp, err := client.PatchWorkspaceProxy(ctx, codersdk.PatchWorkspaceProxy{
 		ID:              data.ID.ValueUUID(),
 		Name:            data.Name.ValueString(),
 		DisplayName:     data.DisplayName.ValueString(),
 		Icon:            data.Icon.ValueString(),
-		RegenerateToken: false,
+		RegenerateToken: regenerateToken,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update workspace proxy: %v", err))
@@ -188,6 +299,13 @@ func (r *WorkspaceProxyResource) Update(ctx context.Context, req resource.Update
 	data.Name = types.StringValue(wsp.Proxy.Name)
 	data.DisplayName = types.StringValue(wsp.Proxy.DisplayName)
 	data.Icon = types.StringValue(wsp.Proxy.IconURL)
+	if regenerateToken {
+		tflog.Info(ctx, "regenerated workspace proxy session token")
+		data.SessionToken = types.StringValue(wsp.ProxyToken)
+	}
+	// The registration attributes are planned from state, so only refresh
+	// the health attributes here.
+	data.setHealth(wsp.Proxy)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
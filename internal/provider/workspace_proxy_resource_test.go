@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/coder/terraform-provider-coderd/integration"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,6 +49,11 @@ func TestAccWorkspaceProxyResource(t *testing.T) {
 	cfg2.Name = ptr.Ref("example-new")
 	cfg2.DisplayName = ptr.Ref("Example WS Proxy New")
 
+	cfg3 := cfg2
+	cfg3.TokenRotationTrigger = ptr.Ref("1")
+
+	var proxyID, sessionToken string
+
 	resource.Test(t, resource.TestCase{
 		IsUnitTest:               true,
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -56,13 +64,58 @@ func TestAccWorkspaceProxyResource(t *testing.T) {
 				Config: cfg1.String(t),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("coderd_workspace_proxy.test", "session_token"),
+					// Nothing runs the proxy, so it never registers.
+					resource.TestCheckResourceAttr("coderd_workspace_proxy.test", "healthy", "false"),
+					resource.TestCheckResourceAttrSet("coderd_workspace_proxy.test", "status"),
+					resource.TestCheckResourceAttr("coderd_workspace_proxy.test", "url", ""),
 				),
 			},
 			// Update and Read testing
 			{
 				Config: cfg2.String(t),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttrSet("coderd_workspace_proxy.test", "session_token")),
+					resource.TestCheckResourceAttrWith("coderd_workspace_proxy.test", "id", func(value string) error {
+						proxyID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("coderd_workspace_proxy.test", "session_token", func(value string) error {
+						sessionToken = value
+						return nil
+					}),
+				),
+			},
+			// Rotate the session token in place
+			{
+				Config: cfg3.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("coderd_workspace_proxy.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("coderd_workspace_proxy.test", tfjsonpath.New("session_token")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("coderd_workspace_proxy.test", "id", func(value string) error {
+						if value != proxyID {
+							return fmt.Errorf("proxy was replaced: ID changed from %s to %s", proxyID, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("coderd_workspace_proxy.test", "session_token", func(value string) error {
+						if value == "" || value == sessionToken {
+							return fmt.Errorf("session token was not regenerated")
+						}
+						return nil
+					}),
+				),
+			},
+			// The unchanged trigger keeps the token
+			{
+				Config: cfg3.String(t),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
 			},
 		},
 	})
@@ -172,9 +225,10 @@ type testAccWorkspaceProxyResourceConfig struct {
 	URL   string
 	Token string
 
-	Name        *string
-	DisplayName *string
-	Icon        *string
+	Name                 *string
+	DisplayName          *string
+	Icon                 *string
+	TokenRotationTrigger *string
 }
 
 func (c testAccWorkspaceProxyResourceConfig) String(t *testing.T) string {
@@ -189,6 +243,7 @@ resource "coderd_workspace_proxy" "test" {
 	name = {{orNull .Name}}
 	display_name = {{orNull .DisplayName}}
 	icon = {{orNull .Icon}}
+	token_rotation_trigger = {{orNull .TokenRotationTrigger}}
 }
 `
 	// Define template functions
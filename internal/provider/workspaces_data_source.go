@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const workspacesPageLimit = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkspacesDataSource{}
+
+func NewWorkspacesDataSource() datasource.DataSource {
+	return &WorkspacesDataSource{}
+}
+
+// WorkspacesDataSource defines the data source implementation.
+type WorkspacesDataSource struct {
+	data *CoderdProviderData
+}
+
+// WorkspacesDataSourceModel describes the data source data model.
+type WorkspacesDataSourceModel struct {
+	Owner    types.String `tfsdk:"owner"`
+	Template types.String `tfsdk:"template"`
+	Status   types.String `tfsdk:"status"`
+	Outdated types.Bool   `tfsdk:"outdated"`
+	Dormant  types.Bool   `tfsdk:"dormant"`
+
+	Workspaces []WorkspaceSummary `tfsdk:"workspaces"`
+}
+
+type WorkspaceSummary struct {
+	ID                      UUID         `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	OrganizationID          UUID         `tfsdk:"organization_id"`
+	OwnerID                 UUID         `tfsdk:"owner_id"`
+	OwnerName               types.String `tfsdk:"owner_name"`
+	TemplateID              UUID         `tfsdk:"template_id"`
+	TemplateName            types.String `tfsdk:"template_name"`
+	TemplateVersionID       UUID         `tfsdk:"template_version_id"`
+	TemplateActiveVersionID UUID         `tfsdk:"template_active_version_id"`
+	Outdated                types.Bool   `tfsdk:"outdated"`
+	LatestBuildStatus       types.String `tfsdk:"latest_build_status"`
+	LatestBuildTransition   types.String `tfsdk:"latest_build_transition"`
+	LastUsedAt              types.Int64  `tfsdk:"last_used_at"`
+	DormantAt               types.Int64  `tfsdk:"dormant_at"`
+	AutostartSchedule       types.String `tfsdk:"autostart_schedule"`
+	TTLMillis               types.Int64  `tfsdk:"ttl_ms"`
+	CreatedAt               types.Int64  `tfsdk:"created_at"`
+}
+
+func (d *WorkspacesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspaces"
+}
+
+func (d *WorkspacesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists workspaces on the Coder deployment, optionally filtered. Only workspaces the provider token can read are returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Only return workspaces owned by the user with this username. `me` refers to the user the provider token belongs to.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Only return workspaces created from templates with this name.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return workspaces whose latest build has this status. Valid values are `pending`, `starting`, `running`, `stopping`, `stopped`, `failed`, `canceling`, `canceled`, `deleting`, and `deleted`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(codersdk.WorkspaceStatusPending),
+						string(codersdk.WorkspaceStatusStarting),
+						string(codersdk.WorkspaceStatusRunning),
+						string(codersdk.WorkspaceStatusStopping),
+						string(codersdk.WorkspaceStatusStopped),
+						string(codersdk.WorkspaceStatusFailed),
+						string(codersdk.WorkspaceStatusCanceling),
+						string(codersdk.WorkspaceStatusCanceled),
+						string(codersdk.WorkspaceStatusDeleting),
+						string(codersdk.WorkspaceStatusDeleted),
+					),
+				},
+			},
+			"outdated": schema.BoolAttribute{
+				MarkdownDescription: "If set, only return workspaces whose latest build is (`true`) or is not (`false`) on an outdated template version.",
+				Optional:            true,
+			},
+			"dormant": schema.BoolAttribute{
+				MarkdownDescription: "If set, only return workspaces that are (`true`) or are not (`false`) dormant.",
+				Optional:            true,
+			},
+			"workspaces": schema.ListNestedAttribute{
+				MarkdownDescription: "Workspaces matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"organization_id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"owner_id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"owner_name": schema.StringAttribute{
+							MarkdownDescription: "Username of the workspace owner.",
+							Computed:            true,
+						},
+						"template_id": schema.StringAttribute{
+							CustomType: UUIDType,
+							Computed:   true,
+						},
+						"template_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"template_version_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the template version used by the latest build.",
+							CustomType:          UUIDType,
+							Computed:            true,
+						},
+						"template_active_version_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the active version of the workspace's template.",
+							CustomType:          UUIDType,
+							Computed:            true,
+						},
+						"outdated": schema.BoolAttribute{
+							MarkdownDescription: "Whether the latest build uses a template version other than the active one.",
+							Computed:            true,
+						},
+						"latest_build_status": schema.StringAttribute{
+							MarkdownDescription: "Status of the latest build, such as `running` or `stopped`.",
+							Computed:            true,
+						},
+						"latest_build_transition": schema.StringAttribute{
+							MarkdownDescription: "Transition of the latest build. One of `start`, `stop` or `delete`.",
+							Computed:            true,
+						},
+						"last_used_at": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp of when the workspace was last used.",
+							Computed:            true,
+						},
+						"dormant_at": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp of when the workspace became dormant. Null if the workspace is not dormant.",
+							Computed:            true,
+						},
+						"autostart_schedule": schema.StringAttribute{
+							MarkdownDescription: "Autostart schedule of the workspace, as a cron expression. Null if autostart is disabled.",
+							Computed:            true,
+						},
+						"ttl_ms": schema.Int64Attribute{
+							MarkdownDescription: "Time a started workspace runs before it is automatically stopped, in milliseconds. Null if autostop is disabled.",
+							Computed:            true,
+						},
+						"created_at": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp of when the workspace was created.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkspacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*CoderdProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CoderdProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+func (d *WorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkspacesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaces, err := listWorkspaces(ctx, d.data.Client, data.toFilter())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workspaces, got error: %s", err))
+		return
+	}
+
+	summaries := make([]WorkspaceSummary, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		// The search API only filters on `dormant:true`, so the negative case
+		// is handled here.
+		if !data.Dormant.IsNull() && !data.Dormant.ValueBool() && workspace.DormantAt != nil {
+			continue
+		}
+		summaries = append(summaries, newWorkspaceSummary(workspace))
+	}
+	data.Workspaces = summaries
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m WorkspacesDataSourceModel) toFilter() codersdk.WorkspaceFilter {
+	var query []string
+	if !m.Outdated.IsNull() {
+		query = append(query, fmt.Sprintf("outdated:%t", m.Outdated.ValueBool()))
+	}
+	if m.Dormant.ValueBool() {
+		query = append(query, "dormant:true")
+	}
+	return codersdk.WorkspaceFilter{
+		Owner:       m.Owner.ValueString(),
+		Template:    m.Template.ValueString(),
+		Status:      m.Status.ValueString(),
+		FilterQuery: strings.Join(query, " "),
+	}
+}
+
+// listWorkspaces pages through every workspace matching filter.
+func listWorkspaces(ctx context.Context, client *codersdk.Client, filter codersdk.WorkspaceFilter) ([]codersdk.Workspace, error) {
+	var workspaces []codersdk.Workspace
+	filter.Limit = workspacesPageLimit
+	for {
+		page, err := client.Workspaces(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, page.Workspaces...)
+		if len(page.Workspaces) < workspacesPageLimit || len(workspaces) >= page.Count {
+			return workspaces, nil
+		}
+		filter.Offset += len(page.Workspaces)
+	}
+}
+
+func newWorkspaceSummary(workspace codersdk.Workspace) WorkspaceSummary {
+	summary := WorkspaceSummary{
+		ID:                      UUIDValue(workspace.ID),
+		Name:                    types.StringValue(workspace.Name),
+		OrganizationID:          UUIDValue(workspace.OrganizationID),
+		OwnerID:                 UUIDValue(workspace.OwnerID),
+		OwnerName:               types.StringValue(workspace.OwnerName),
+		TemplateID:              UUIDValue(workspace.TemplateID),
+		TemplateName:            types.StringValue(workspace.TemplateName),
+		TemplateVersionID:       UUIDValue(workspace.LatestBuild.TemplateVersionID),
+		TemplateActiveVersionID: UUIDValue(workspace.TemplateActiveVersionID),
+		Outdated:                types.BoolValue(workspace.Outdated),
+		LatestBuildStatus:       types.StringValue(string(workspace.LatestBuild.Status)),
+		LatestBuildTransition:   types.StringValue(string(workspace.LatestBuild.Transition)),
+		LastUsedAt:              types.Int64Value(workspace.LastUsedAt.Unix()),
+		DormantAt:               types.Int64Null(),
+		AutostartSchedule:       types.StringPointerValue(workspace.AutostartSchedule),
+		TTLMillis:               types.Int64PointerValue(workspace.TTLMillis),
+		CreatedAt:               types.Int64Value(workspace.CreatedAt.Unix()),
+	}
+	if workspace.DormantAt != nil {
+		summary.DormantAt = types.Int64Value(workspace.DormantAt.Unix())
+	}
+	return summary
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/terraform-provider-coderd/integration"
+)
+
+func TestAccWorkspacesDataSource(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests are disabled.")
+	}
+	ctx := t.Context()
+	client := integration.StartCoder(ctx, t, "workspaces_data_acc")
+	firstUser, err := client.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	orgID := firstUser.OrganizationIDs[0]
+
+	versionReq := newVersionRequest{
+		OrganizationID: orgID,
+		Version: &TemplateVersion{
+			Name:      types.StringValue("main"),
+			Message:   types.StringValue("Initial commit"),
+			Directory: types.StringValue("../../integration/template-test/example-template/"),
+			TerraformVariables: mustVariablesToSet([]Variable{
+				{
+					Name:  types.StringValue("name"),
+					Value: types.StringValue("world"),
+				},
+			}),
+		},
+	}
+	version, _, err := newVersion(ctx, client, versionReq)
+	require.NoError(t, err)
+	tpl, err := client.CreateTemplate(ctx, orgID, codersdk.CreateTemplateRequest{
+		Name:      "workspaces-template",
+		VersionID: version.ID,
+	})
+	require.NoError(t, err)
+
+	ws, err := client.CreateUserWorkspace(ctx, codersdk.Me, codersdk.CreateWorkspaceRequest{
+		TemplateID: tpl.ID,
+		Name:       "outdated-ws",
+	})
+	require.NoError(t, err)
+
+	// Promote a new version so the workspace above becomes outdated.
+	versionReq.Version.Name = types.StringValue("second")
+	versionReq.TemplateID = &tpl.ID
+	version2, _, err := newVersion(ctx, client, versionReq)
+	require.NoError(t, err)
+	require.NoError(t, markActive(ctx, client, tpl.ID, version2.ID))
+
+	t.Run("AllOk", func(t *testing.T) {
+		cfg := testAccWorkspacesDataSourceConfig{
+			URL:   client.URL.String(),
+			Token: client.SessionToken(),
+		}
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: cfg.String(t),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.#", "1"),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.id", ws.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.name", "outdated-ws"),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.owner_id", firstUser.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.owner_name", firstUser.Username),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.template_id", tpl.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.template_name", tpl.Name),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.template_version_id", version.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.template_active_version_id", version2.ID.String()),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.outdated", "true"),
+						resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.0.latest_build_transition", "start"),
+						resource.TestCheckNoResourceAttr("data.coderd_workspaces.test", "workspaces.0.dormant_at"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("FiltersOk", func(t *testing.T) {
+		for _, tt := range []struct {
+			name  string
+			cfg   testAccWorkspacesDataSourceConfig
+			count string
+		}{
+			{
+				name:  "OutdatedTrue",
+				cfg:   testAccWorkspacesDataSourceConfig{Outdated: ptr.Ref(true)},
+				count: "1",
+			},
+			{
+				name:  "OutdatedFalse",
+				cfg:   testAccWorkspacesDataSourceConfig{Outdated: ptr.Ref(false)},
+				count: "0",
+			},
+			{
+				name:  "DormantFalse",
+				cfg:   testAccWorkspacesDataSourceConfig{Dormant: ptr.Ref(false)},
+				count: "1",
+			},
+			{
+				name:  "DormantTrue",
+				cfg:   testAccWorkspacesDataSourceConfig{Dormant: ptr.Ref(true)},
+				count: "0",
+			},
+			{
+				name:  "OwnerAndTemplate",
+				cfg:   testAccWorkspacesDataSourceConfig{Owner: ptr.Ref(firstUser.Username), Template: ptr.Ref(tpl.Name)},
+				count: "1",
+			},
+			{
+				name:  "OtherTemplate",
+				cfg:   testAccWorkspacesDataSourceConfig{Template: ptr.Ref("does-not-exist")},
+				count: "0",
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := tt.cfg
+				cfg.URL = client.URL.String()
+				cfg.Token = client.SessionToken()
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					PreCheck:                 func() { testAccPreCheck(t) },
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					Steps: []resource.TestStep{
+						{
+							Config: cfg.String(t),
+							Check:  resource.TestCheckResourceAttr("data.coderd_workspaces.test", "workspaces.#", tt.count),
+						},
+					},
+				})
+			})
+		}
+	})
+}
+
+type testAccWorkspacesDataSourceConfig struct {
+	URL   string
+	Token string
+
+	Owner    *string
+	Template *string
+	Status   *string
+	Outdated *bool
+	Dormant  *bool
+}
+
+func (c testAccWorkspacesDataSourceConfig) String(t *testing.T) string {
+	t.Helper()
+	tpl := `
+provider coderd {
+	url   = "{{.URL}}"
+	token = "{{.Token}}"
+}
+
+data "coderd_workspaces" "test" {
+	owner    = {{orNull .Owner}}
+	template = {{orNull .Template}}
+	status   = {{orNull .Status}}
+	outdated = {{orNull .Outdated}}
+	dormant  = {{orNull .Dormant}}
+}`
+
+	funcMap := template.FuncMap{
+		"orNull": PrintOrNull,
+	}
+
+	buf := strings.Builder{}
+	tmpl, err := template.New("workspacesDataSource").Funcs(funcMap).Parse(tpl)
+	require.NoError(t, err)
+
+	err = tmpl.Execute(&buf, c)
+	require.NoError(t, err)
+	return buf.String()
+}